@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Shopify/sarama"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Batch attributes.
+const (
+	BatchMessageCountKey = attribute.Key("messaging.batch.message_count")
+	BatchOffsetsKey      = attribute.Key("messaging.kafka.batch.offsets")
+)
+
+// StartBatch starts a span for the processing of a batch of consumed messages.
+// The span is a child of the span in ctx, if any, and is linked to the span
+// context extracted from every message: the context of its receive span when
+// the message was consumed through a wrapped consumer, or the context of its
+// producer otherwise. The caller is responsible for ending the returned span.
+//
+// The span carries the number of messages and, for every partition, the range
+// of offsets in the batch as "topic/partition:first-last". Nil messages are
+// ignored.
+func StartBatch(ctx context.Context, msgs []*sarama.ConsumerMessage, opts ...Option) (context.Context, trace.Span) {
+	cfg := newConfig(opts...)
+
+	type offsetRange struct{ first, last int64 }
+	ranges := make(map[topicPartition]*offsetRange)
+	topics := make(map[string]struct{})
+	links := make([]trace.Link, 0, len(msgs))
+	count := 0
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		count++
+
+		sc := trace.SpanContextFromContext(cfg.Propagators.Extract(context.Background(), NewConsumerMessageCarrier(msg)))
+		if sc.IsValid() {
+			links = append(links, trace.Link{
+				SpanContext: sc,
+				Attributes: []attribute.KeyValue{
+					semconv.MessagingMessageIDKey.String(fmt.Sprint(msg.Offset)),
+					semconv.MessagingKafkaPartitionKey.Int64(int64(msg.Partition)),
+				},
+			})
+		}
+
+		topics[msg.Topic] = struct{}{}
+		tp := topicPartition{topic: msg.Topic, partition: msg.Partition}
+		if r, ok := ranges[tp]; ok {
+			if msg.Offset < r.first {
+				r.first = msg.Offset
+			}
+			if msg.Offset > r.last {
+				r.last = msg.Offset
+			}
+		} else {
+			ranges[tp] = &offsetRange{first: msg.Offset, last: msg.Offset}
+		}
+	}
+
+	offsets := make([]string, 0, len(ranges))
+	for tp, r := range ranges {
+		offsets = append(offsets, fmt.Sprintf("%s/%d:%d-%d", tp.topic, tp.partition, r.first, r.last))
+	}
+	sort.Strings(offsets)
+
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKindTopic,
+		semconv.MessagingOperationProcess,
+		BatchMessageCountKey.Int(count),
+		BatchOffsetsKey.StringSlice(offsets),
+	}
+	name := "batch process"
+	if len(topics) == 1 {
+		for topic := range topics {
+			attrs = append(attrs, semconv.MessagingDestinationKey.String(topic))
+			name = fmt.Sprintf("%s process", topic)
+		}
+	}
+
+	return cfg.Tracer.Start(ctx, name,
+		trace.WithAttributes(attrs...),
+		trace.WithLinks(links...),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	)
+}
@@ -33,8 +33,11 @@ func (pc *partitionConsumer) Messages() <-chan *sarama.ConsumerMessage {
 // message to be traced.
 func WrapPartitionConsumer(pc sarama.PartitionConsumer, opts ...Option) sarama.PartitionConsumer {
 	cfg := newConfig(opts...)
+	return wrapPartitionConsumer(pc, cfg, newInstruments(cfg.MeterProvider, cfg.ConsumerGroup))
+}
 
-	dispatcher := newConsumerMessagesDispatcherWrapper(pc, cfg)
+func wrapPartitionConsumer(pc sarama.PartitionConsumer, cfg config, instruments *instruments) sarama.PartitionConsumer {
+	dispatcher := newConsumerMessagesDispatcherWrapper(pc, cfg, instruments)
 	go dispatcher.Run()
 	wrapped := &partitionConsumer{
 		PartitionConsumer: pc,
@@ -46,7 +49,8 @@ func WrapPartitionConsumer(pc sarama.PartitionConsumer, opts ...Option) sarama.P
 type consumer struct {
 	sarama.Consumer
 
-	opts []Option
+	cfg         config
+	instruments *instruments
 }
 
 // ConsumePartition invokes Consumer.ConsumePartition and wraps the resulting
@@ -56,14 +60,16 @@ func (c *consumer) ConsumePartition(topic string, partition int32, offset int64)
 	if err != nil {
 		return nil, err
 	}
-	return WrapPartitionConsumer(pc, c.opts...), nil
+	return wrapPartitionConsumer(pc, c.cfg, c.instruments), nil
 }
 
 // WrapConsumer wraps a sarama.Consumer wrapping any PartitionConsumer created
 // via Consumer.ConsumePartition.
 func WrapConsumer(c sarama.Consumer, opts ...Option) sarama.Consumer {
+	cfg := newConfig(opts...)
 	return &consumer{
-		Consumer: c,
-		opts:     opts,
+		Consumer:    c,
+		cfg:         cfg,
+		instruments: newInstruments(cfg.MeterProvider, cfg.ConsumerGroup),
 	}
 }
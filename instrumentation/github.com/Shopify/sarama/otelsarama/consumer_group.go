@@ -15,37 +15,114 @@
 package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
 	"github.com/Shopify/sarama"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Consumer group attributes.
+const (
+	ConsumerGroupGenerationIDKey = attribute.Key("messaging.kafka.consumer_group.generation_id")
+	ConsumerGroupMemberIDKey     = attribute.Key("messaging.kafka.consumer_group.member_id")
+	ConsumerGroupClaimsKey       = attribute.Key("messaging.kafka.consumer_group.claims")
+	ConsumerGroupAssignedKey     = attribute.Key("messaging.kafka.consumer_group.assigned")
+	ConsumerGroupRevokedKey      = attribute.Key("messaging.kafka.consumer_group.revoked")
 )
 
 type consumerGroupHandler struct {
 	sarama.ConsumerGroupHandler
 
-	cfg config
+	cfg         config
+	instruments *instruments
+
+	mu     sync.Mutex
+	claims []string
+}
+
+// Setup traces the set up of a new consumer group session, which happens
+// after every rebalance, recording the partitions assigned to and revoked
+// from this member since the previous session.
+// It implements parts of `ConsumerGroupHandler`.
+func (h *consumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	claims := flattenClaims(session.Claims())
+
+	h.mu.Lock()
+	assigned, revoked := diffClaims(h.claims, claims)
+	h.claims = claims
+	h.mu.Unlock()
+
+	attrs := append(sessionAttrs(session),
+		ConsumerGroupAssignedKey.StringSlice(assigned),
+		ConsumerGroupRevokedKey.StringSlice(revoked),
+	)
+	_, span := h.cfg.Tracer.Start(session.Context(), "consumer group setup", trace.WithAttributes(attrs...))
+	defer span.End()
+
+	h.instruments.rebalances.Add(context.Background(), 1, semconv.MessagingSystemKey.String("kafka"))
+
+	err := h.ConsumerGroupHandler.Setup(h.wrapSession(session))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// Cleanup traces the end of a consumer group session.
+// It implements parts of `ConsumerGroupHandler`.
+func (h *consumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	_, span := h.cfg.Tracer.Start(session.Context(), "consumer group cleanup", trace.WithAttributes(sessionAttrs(session)...))
+	defer span.End()
+
+	err := h.ConsumerGroupHandler.Cleanup(h.wrapSession(session))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
 }
 
 // ConsumeClaim wraps the session and claim to add instruments for messages.
 // It implements parts of `ConsumerGroupHandler`.
 func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	// Wrap claim
-	dispatcher := newConsumerMessagesDispatcherWrapper(claim, h.cfg)
+	dispatcher := newConsumerMessagesDispatcherWrapper(claim, h.cfg, h.instruments)
 	go dispatcher.Run()
 	claim = &consumerGroupClaim{
 		ConsumerGroupClaim: claim,
 		dispatcher:         dispatcher,
 	}
 
-	return h.ConsumerGroupHandler.ConsumeClaim(session, claim)
+	return h.ConsumerGroupHandler.ConsumeClaim(h.wrapSession(session), claim)
+}
+
+// wrapSession returns a session tracing offset commits when enabled with
+// WithCommitSpans.
+func (h *consumerGroupHandler) wrapSession(session sarama.ConsumerGroupSession) sarama.ConsumerGroupSession {
+	if !h.cfg.CommitSpans {
+		return session
+	}
+	return &consumerGroupSession{ConsumerGroupSession: session, cfg: h.cfg}
 }
 
 // WrapConsumerGroupHandler wraps a sarama.ConsumerGroupHandler causing each received
-// message to be traced.
+// message, as well as the set up and clean up of every session, to be traced.
 func WrapConsumerGroupHandler(handler sarama.ConsumerGroupHandler, opts ...Option) sarama.ConsumerGroupHandler {
 	cfg := newConfig(opts...)
 
 	return &consumerGroupHandler{
 		ConsumerGroupHandler: handler,
 		cfg:                  cfg,
+		instruments:          newInstruments(cfg.MeterProvider, cfg.ConsumerGroup),
 	}
 }
 
@@ -57,3 +134,76 @@ type consumerGroupClaim struct {
 func (c *consumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
 	return c.dispatcher.Messages()
 }
+
+type consumerGroupSession struct {
+	sarama.ConsumerGroupSession
+	cfg config
+}
+
+// MarkMessage traces the marking of a message as consumed, as a child of the
+// span created when the message was received.
+func (s *consumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	ctx := s.cfg.Propagators.Extract(context.Background(), NewConsumerMessageCarrier(msg))
+	_, span := s.cfg.Tracer.Start(ctx, fmt.Sprintf("%s mark", msg.Topic), trace.WithAttributes(
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKindTopic,
+		semconv.MessagingDestinationKey.String(msg.Topic),
+		semconv.MessagingMessageIDKey.String(strconv.FormatInt(msg.Offset, 10)),
+		semconv.MessagingKafkaPartitionKey.Int64(int64(msg.Partition)),
+	))
+	defer span.End()
+
+	s.ConsumerGroupSession.MarkMessage(msg, metadata)
+}
+
+// Commit traces a synchronous commit of the marked offsets.
+func (s *consumerGroupSession) Commit() {
+	_, span := s.cfg.Tracer.Start(s.Context(), "consumer group commit", trace.WithAttributes(sessionAttrs(s.ConsumerGroupSession)...))
+	defer span.End()
+
+	s.ConsumerGroupSession.Commit()
+}
+
+func sessionAttrs(session sarama.ConsumerGroupSession) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		ConsumerGroupGenerationIDKey.Int64(int64(session.GenerationID())),
+		ConsumerGroupMemberIDKey.String(session.MemberID()),
+		ConsumerGroupClaimsKey.StringSlice(flattenClaims(session.Claims())),
+	}
+}
+
+// flattenClaims returns the claimed partitions as sorted "topic/partition"
+// strings.
+func flattenClaims(claims map[string][]int32) []string {
+	var out []string
+	for topic, partitions := range claims {
+		for _, p := range partitions {
+			out = append(out, topic+"/"+strconv.FormatInt(int64(p), 10))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// diffClaims returns the partitions present in current but not in previous,
+// and those present in previous but not in current.
+func diffClaims(previous, current []string) (assigned, revoked []string) {
+	prev := make(map[string]struct{}, len(previous))
+	for _, c := range previous {
+		prev[c] = struct{}{}
+	}
+	cur := make(map[string]struct{}, len(current))
+	for _, c := range current {
+		cur[c] = struct{}{}
+		if _, ok := prev[c]; !ok {
+			assigned = append(assigned, c)
+		}
+	}
+	for _, c := range previous {
+		if _, ok := cur[c]; !ok {
+			revoked = append(revoked, c)
+		}
+	}
+	return assigned, revoked
+}
@@ -30,18 +30,26 @@ type consumerMessagesDispatcher interface {
 	Messages() <-chan *sarama.ConsumerMessage
 }
 
+// highWaterMarker is implemented by both sarama.PartitionConsumer and
+// sarama.ConsumerGroupClaim.
+type highWaterMarker interface {
+	HighWaterMarkOffset() int64
+}
+
 type consumerMessagesDispatcherWrapper struct {
 	d        consumerMessagesDispatcher
 	messages chan *sarama.ConsumerMessage
 
-	cfg config
+	cfg         config
+	instruments *instruments
 }
 
-func newConsumerMessagesDispatcherWrapper(d consumerMessagesDispatcher, cfg config) *consumerMessagesDispatcherWrapper {
+func newConsumerMessagesDispatcherWrapper(d consumerMessagesDispatcher, cfg config, instruments *instruments) *consumerMessagesDispatcherWrapper {
 	return &consumerMessagesDispatcherWrapper{
-		d:        d,
-		messages: make(chan *sarama.ConsumerMessage),
-		cfg:      cfg,
+		d:           d,
+		messages:    make(chan *sarama.ConsumerMessage),
+		cfg:         cfg,
+		instruments: instruments,
 	}
 }
 
@@ -53,8 +61,13 @@ func (w *consumerMessagesDispatcherWrapper) Messages() <-chan *sarama.ConsumerMe
 
 func (w *consumerMessagesDispatcherWrapper) Run() {
 	msgs := w.d.Messages()
+	hwm, trackLag := w.d.(highWaterMarker)
+	var last *sarama.ConsumerMessage
 
 	for msg := range msgs {
+		last = msg
+		w.instruments.recordConsumed(context.Background(), msg.Topic, consumerMsgPayloadSize(msg))
+
 		// Extract a span context from message to link.
 		carrier := NewConsumerMessageCarrier(msg)
 		parentSpanContext := w.cfg.Propagators.Extract(context.Background(), carrier)
@@ -68,6 +81,9 @@ func (w *consumerMessagesDispatcherWrapper) Run() {
 			semconv.MessagingMessageIDKey.String(strconv.FormatInt(msg.Offset, 10)),
 			semconv.MessagingKafkaPartitionKey.Int64(int64(msg.Partition)),
 		}
+		if w.cfg.capturePayload() {
+			attrs = append(attrs, consumerPayloadAttributes(w.cfg, msg)...)
+		}
 		opts := []trace.SpanStartOption{
 			trace.WithAttributes(attrs...),
 			trace.WithSpanKind(trace.SpanKindConsumer),
@@ -81,6 +97,25 @@ func (w *consumerMessagesDispatcherWrapper) Run() {
 		w.messages <- msg
 
 		span.End()
+
+		if trackLag {
+			w.instruments.lag.set(msg.Topic, msg.Partition, hwm.HighWaterMarkOffset(), msg.Offset)
+		}
+	}
+	if last != nil && trackLag {
+		w.instruments.lag.release(last.Topic, last.Partition)
 	}
 	close(w.messages)
 }
+
+// consumerMsgPayloadSize returns the size in bytes of the key, value and
+// headers of a consumed message.
+func consumerMsgPayloadSize(msg *sarama.ConsumerMessage) int {
+	size := len(msg.Key) + len(msg.Value)
+	for _, h := range msg.Headers {
+		if h != nil {
+			size += len(h.Key) + len(h.Value)
+		}
+	}
+	return size
+}
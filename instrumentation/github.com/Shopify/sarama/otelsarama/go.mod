@@ -4,8 +4,10 @@ go 1.18
 
 require (
 	github.com/Shopify/sarama v1.38.0
+	github.com/helios/go-sdk/data-utils v1.0.2
 	github.com/stretchr/testify v1.8.1
 	go.opentelemetry.io/otel v1.11.2
+	go.opentelemetry.io/otel/metric v0.34.0
 	go.opentelemetry.io/otel/trace v1.11.2
 )
 
@@ -27,11 +29,13 @@ require (
 	github.com/jcmturner/rpc/v2 v2.0.3 // indirect
 	github.com/klauspost/compress v1.15.11 // indirect
 	github.com/kr/text v0.2.0 // indirect
+	github.com/ohler55/ojg v1.17.4 // indirect
 	github.com/pierrec/lz4/v4 v4.1.17 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rcrowley/go-metrics v0.0.0-20201227073835-cf1acfcdf475 // indirect
 	github.com/rogpeppe/go-internal v1.9.0 // indirect
 	golang.org/x/crypto v0.0.0-20220722155217-630584e8d5aa // indirect
+	golang.org/x/exp v0.0.0-20230203172020-98cc5a0785f9 // indirect
 	golang.org/x/net v0.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// Kafka metrics.
+const (
+	MessagesSent     = "messaging.kafka.messages.sent"       // Number of messages acknowledged by the broker
+	MessagesFailed   = "messaging.kafka.messages.failed"     // Number of messages that failed to be produced
+	MessagesReceived = "messaging.kafka.messages.received"   // Number of messages consumed
+	PublishDuration  = "messaging.kafka.publish.duration"    // Time from sending a message to its acknowledgement, milliseconds
+	MessageSize      = "messaging.kafka.message.size"        // Approximate size of produced and consumed messages, bytes
+	ConsumerLag      = "messaging.kafka.consumer.lag"        // High water mark offset minus the next offset to process
+	Rebalances       = "messaging.kafka.consumer.rebalances" // Number of consumer group sessions set up
+)
+
+type instruments struct {
+	// sent is the number of messages acknowledged by the broker.
+	sent syncint64.Counter
+
+	// failed is the number of messages that could not be produced.
+	failed syncint64.Counter
+
+	// received is the number of messages handed to the consumer.
+	received syncint64.Counter
+
+	// publishDuration is the time taken by the broker to acknowledge a message.
+	publishDuration syncfloat64.Histogram
+
+	// messageSize is the size of produced and consumed messages.
+	messageSize syncint64.Histogram
+
+	// rebalances is the number of consumer group sessions set up.
+	rebalances syncint64.Counter
+
+	// lag holds the last known lag of every consumed partition.
+	lag *consumerLag
+}
+
+// newInstruments will create instruments using a meter
+// from the given provider p. The consumer lag recorded with them is reported
+// for the consumer group, if any.
+func newInstruments(p metric.MeterProvider, group string) *instruments {
+	meter := p.Meter(
+		defaultTracerName,
+		metric.WithInstrumentationVersion(SemVersion()),
+	)
+	instruments := &instruments{lag: newConsumerLag(group)}
+	var err error
+
+	if instruments.sent, err = meter.SyncInt64().Counter(
+		MessagesSent,
+		instrument.WithDescription("Number of messages acknowledged by the broker"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.failed, err = meter.SyncInt64().Counter(
+		MessagesFailed,
+		instrument.WithDescription("Number of messages that failed to be produced"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.received, err = meter.SyncInt64().Counter(
+		MessagesReceived,
+		instrument.WithDescription("Number of messages consumed"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.publishDuration, err = meter.SyncFloat64().Histogram(
+		PublishDuration,
+		instrument.WithDescription("Time from sending a message to its acknowledgement by the broker"),
+		instrument.WithUnit(unit.Milliseconds),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.messageSize, err = meter.SyncInt64().Histogram(
+		MessageSize,
+		instrument.WithDescription("Approximate size of produced and consumed messages"),
+		instrument.WithUnit(unit.Bytes),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.rebalances, err = meter.SyncInt64().Counter(
+		Rebalances,
+		instrument.WithDescription("Number of consumer group sessions set up after a rebalance"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	lag, err := meter.AsyncInt64().Gauge(
+		ConsumerLag,
+		instrument.WithDescription("Number of messages between the last processed offset and the high water mark of a partition"),
+	)
+	if err != nil {
+		otel.Handle(err)
+		return instruments
+	}
+	if err = meter.RegisterCallback(
+		[]instrument.Asynchronous{lag},
+		func(ctx context.Context) {
+			instruments.lag.observe(ctx, lag)
+		},
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return instruments
+}
+
+func topicAttrs(topic string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.MessagingSystemKey.String("kafka"),
+		semconv.MessagingDestinationKey.String(topic),
+	}
+}
+
+func (i *instruments) recordProduced(ctx context.Context, topic string, size int, start time.Time, err error) {
+	attrs := topicAttrs(topic)
+	if err != nil {
+		i.failed.Add(ctx, 1, attrs...)
+		return
+	}
+
+	i.sent.Add(ctx, 1, attrs...)
+	i.messageSize.Record(ctx, int64(size), append(attrs, semconv.MessagingOperationKey.String("send"))...)
+	// Use floating point division here for higher precision (instead of Millisecond method).
+	i.publishDuration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), attrs...)
+}
+
+func (i *instruments) recordConsumed(ctx context.Context, topic string, size int) {
+	attrs := topicAttrs(topic)
+	i.received.Add(ctx, 1, attrs...)
+	i.messageSize.Record(ctx, int64(size), append(attrs, semconv.MessagingOperationReceive)...)
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// consumerLag tracks the lag of the partitions consumed by a wrapped consumer
+// or consumer group handler until they are released.
+type consumerLag struct {
+	group string
+
+	mu   sync.Mutex
+	lags map[topicPartition]int64
+}
+
+func newConsumerLag(group string) *consumerLag {
+	return &consumerLag{group: group, lags: make(map[topicPartition]int64)}
+}
+
+// set records the lag of a partition after the message at offset has been
+// processed. highWaterMark is the offset of the next message to be produced.
+func (l *consumerLag) set(topic string, partition int32, highWaterMark, offset int64) {
+	lag := highWaterMark - offset - 1
+	if lag < 0 {
+		lag = 0
+	}
+
+	l.mu.Lock()
+	l.lags[topicPartition{topic: topic, partition: partition}] = lag
+	l.mu.Unlock()
+}
+
+// release stops reporting the lag of a partition.
+func (l *consumerLag) release(topic string, partition int32) {
+	l.mu.Lock()
+	delete(l.lags, topicPartition{topic: topic, partition: partition})
+	l.mu.Unlock()
+}
+
+func (l *consumerLag) observe(ctx context.Context, gauge asyncint64.Gauge) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for tp, lag := range l.lags {
+		attrs := append(topicAttrs(tp.topic), semconv.MessagingKafkaPartitionKey.Int64(int64(tp.partition)))
+		if l.group != "" {
+			attrs = append(attrs, semconv.MessagingKafkaConsumerGroupKey.String(l.group))
+		}
+		gauge.Observe(ctx, lag, attrs...)
+	}
+}
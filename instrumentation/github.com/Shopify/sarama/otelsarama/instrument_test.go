@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsarama
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+func TestConsumerLag(t *testing.T) {
+	lag := newConsumerLag("")
+
+	lag.set(topic, 0, 10, 4)
+	lag.set(topic, 1, 10, 9)
+	lag.set(topic, 2, 10, 12)
+	assert.Equal(t, map[topicPartition]int64{
+		{topic: topic, partition: 0}: 5,
+		{topic: topic, partition: 1}: 0,
+		{topic: topic, partition: 2}: 0,
+	}, lag.lags)
+
+	lag.release(topic, 0)
+	assert.NotContains(t, lag.lags, topicPartition{topic: topic, partition: 0})
+}
+
+func TestConsumerMsgPayloadSize(t *testing.T) {
+	msg := &sarama.ConsumerMessage{
+		Key:   []byte("key"),
+		Value: []byte("value"),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("h"), Value: []byte("v")},
+			nil,
+		},
+	}
+
+	assert.Equal(t, 10, consumerMsgPayloadSize(msg))
+}
+
+func TestNewInstrumentsPerWrapper(t *testing.T) {
+	p := metric.NewNoopMeterProvider()
+	a, b := newInstruments(p, "a"), newInstruments(p, "b")
+	assert.NotSame(t, a.lag, b.lag)
+
+	a.lag.set(topic, 0, 10, 4)
+	assert.Empty(t, b.lag.lags)
+}
+
+type recordedGauge struct {
+	asyncint64.Gauge
+	attrs [][]attribute.KeyValue
+}
+
+func (g *recordedGauge) Observe(_ context.Context, _ int64, attrs ...attribute.KeyValue) {
+	g.attrs = append(g.attrs, attrs)
+}
+
+func TestConsumerLagGroup(t *testing.T) {
+	for group, want := range map[string]bool{"": false, "orders": true} {
+		lag := newConsumerLag(group)
+		lag.set(topic, 0, 10, 4)
+
+		gauge := &recordedGauge{}
+		lag.observe(context.Background(), gauge)
+		require.Len(t, gauge.attrs, 1)
+		attrs := attribute.NewSet(gauge.attrs[0]...)
+		v, ok := attrs.Value(semconv.MessagingKafkaConsumerGroupKey)
+		assert.Equal(t, want, ok)
+		if want {
+			assert.Equal(t, group, v.AsString())
+		}
+	}
+}
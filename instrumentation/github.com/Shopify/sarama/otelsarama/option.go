@@ -16,6 +16,8 @@ package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github
 
 import (
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -24,7 +26,12 @@ const defaultTracerName = "go.opentelemetry.io/contrib/instrumentation/github.co
 
 type config struct {
 	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
 	Propagators    propagation.TextMapPropagator
+	CapturePayload bool
+	PayloadMaxSize int
+	CommitSpans    bool
+	ConsumerGroup  string
 
 	Tracer trace.Tracer
 }
@@ -34,6 +41,7 @@ func newConfig(opts ...Option) config {
 	cfg := config{
 		Propagators:    otel.GetTextMapPropagator(),
 		TracerProvider: otel.GetTracerProvider(),
+		MeterProvider:  global.MeterProvider(),
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
@@ -68,6 +76,18 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating the
+// instruments that record produced and consumed messages, publish latency,
+// message sizes and consumer lag. If none is specified, the global provider
+// is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithPropagators specifies propagators to use for extracting
 // information from the HTTP requests. If none are specified, global
 // ones will be used.
@@ -78,3 +98,45 @@ func WithPropagators(propagators propagation.TextMapPropagator) Option {
 		}
 	})
 }
+
+// WithPayloadCapture enables recording the key, the value and the headers of
+// produced and consumed messages on their spans. Values that are neither JSON
+// nor text are skipped, as are the headers used for context propagation.
+// Captured contents are obfuscated and nothing is captured when
+// HS_METADATA_ONLY is set.
+func WithPayloadCapture() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CapturePayload = true
+	})
+}
+
+// WithPayloadMaxSize specifies the maximum size, in bytes, of captured message
+// contents. Larger contents are truncated. If none is specified,
+// DefaultPayloadMaxSize is used.
+func WithPayloadMaxSize(size int) Option {
+	return optionFunc(func(cfg *config) {
+		if size > 0 {
+			cfg.PayloadMaxSize = size
+		}
+	})
+}
+
+// WithCommitSpans enables tracing of the MarkMessage and Commit calls made on
+// the sarama.ConsumerGroupSession passed to a handler wrapped with
+// WrapConsumerGroupHandler. MarkMessage spans are children of the span of
+// the marked message.
+func WithCommitSpans() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CommitSpans = true
+	})
+}
+
+// WithConsumerGroup sets the name of the consumer group whose handler is
+// wrapped with WrapConsumerGroupHandler. It is recorded as the
+// messaging.kafka.consumer_group attribute of the consumer lag, which sarama
+// does not expose to the handler.
+func WithConsumerGroup(group string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.ConsumerGroup = group
+	})
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -45,6 +47,7 @@ func (fakeTracer) Start(ctx context.Context, spanName string, opts ...trace.Span
 
 func TestNewConfig(t *testing.T) {
 	tp := fakeTracerProvider{}
+	mp := metric.NewNoopMeterProvider()
 	prop := propagation.NewCompositeTextMapPropagator()
 
 	testCases := []struct {
@@ -59,6 +62,7 @@ func TestNewConfig(t *testing.T) {
 			},
 			expected: config{
 				TracerProvider: tp,
+				MeterProvider:  global.MeterProvider(),
 				Tracer:         tp.Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
 				Propagators:    otel.GetTextMapPropagator(),
 			},
@@ -70,6 +74,31 @@ func TestNewConfig(t *testing.T) {
 			},
 			expected: config{
 				TracerProvider: otel.GetTracerProvider(),
+				MeterProvider:  global.MeterProvider(),
+				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
+				Propagators:    otel.GetTextMapPropagator(),
+			},
+		},
+		{
+			name: "with meter provider",
+			opts: []Option{
+				WithMeterProvider(mp),
+			},
+			expected: config{
+				TracerProvider: otel.GetTracerProvider(),
+				MeterProvider:  mp,
+				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
+				Propagators:    otel.GetTextMapPropagator(),
+			},
+		},
+		{
+			name: "with empty meter provider",
+			opts: []Option{
+				WithMeterProvider(nil),
+			},
+			expected: config{
+				TracerProvider: otel.GetTracerProvider(),
+				MeterProvider:  global.MeterProvider(),
 				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
 				Propagators:    otel.GetTextMapPropagator(),
 			},
@@ -81,6 +110,7 @@ func TestNewConfig(t *testing.T) {
 			},
 			expected: config{
 				TracerProvider: otel.GetTracerProvider(),
+				MeterProvider:  global.MeterProvider(),
 				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
 				Propagators:    prop,
 			},
@@ -92,8 +122,22 @@ func TestNewConfig(t *testing.T) {
 			},
 			expected: config{
 				TracerProvider: otel.GetTracerProvider(),
+				MeterProvider:  global.MeterProvider(),
+				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
+				Propagators:    otel.GetTextMapPropagator(),
+			},
+		},
+		{
+			name: "with consumer group",
+			opts: []Option{
+				WithConsumerGroup("orders"),
+			},
+			expected: config{
+				TracerProvider: otel.GetTracerProvider(),
+				MeterProvider:  global.MeterProvider(),
 				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(SemVersion())),
 				Propagators:    otel.GetTextMapPropagator(),
+				ConsumerGroup:  "orders",
 			},
 		},
 	}
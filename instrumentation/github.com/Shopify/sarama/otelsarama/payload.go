@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Shopify/sarama"
+	datautils "github.com/helios/go-sdk/data-utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// Message content attributes.
+const (
+	MessageKeyKey     = semconv.MessagingKafkaMessageKeyKey
+	MessagePayloadKey = attribute.Key("messaging.payload")
+	MessageHeadersKey = attribute.Key("messaging.kafka.message.headers")
+)
+
+// DefaultPayloadMaxSize is the maximum size, in bytes, of a captured message
+// value when no size is configured with WithPayloadMaxSize.
+const DefaultPayloadMaxSize = 8 * 1024
+
+// capturePayload reports whether message contents should be recorded. Capture
+// is disabled altogether when HS_METADATA_ONLY is set.
+func (cfg config) capturePayload() bool {
+	return cfg.CapturePayload && os.Getenv("HS_METADATA_ONLY") != "true"
+}
+
+// producerPayloadAttributes returns the content attributes of a message about
+// to be produced. Keys and values that fail to encode are not captured.
+func producerPayloadAttributes(cfg config, msg *sarama.ProducerMessage) []attribute.KeyValue {
+	var key, value []byte
+	if msg.Key != nil {
+		key, _ = msg.Key.Encode()
+	}
+	if msg.Value != nil {
+		value, _ = msg.Value.Encode()
+	}
+	headers := make([]*sarama.RecordHeader, len(msg.Headers))
+	for i := range msg.Headers {
+		headers[i] = &msg.Headers[i]
+	}
+	return payloadAttributes(cfg, key, value, headers)
+}
+
+// consumerPayloadAttributes returns the content attributes of a consumed
+// message.
+func consumerPayloadAttributes(cfg config, msg *sarama.ConsumerMessage) []attribute.KeyValue {
+	return payloadAttributes(cfg, msg.Key, msg.Value, msg.Headers)
+}
+
+func payloadAttributes(cfg config, key, value []byte, headers []*sarama.RecordHeader) []attribute.KeyValue {
+	maxSize := cfg.PayloadMaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultPayloadMaxSize
+	}
+
+	var attrs []attribute.KeyValue
+	if s, ok := printable(key); ok {
+		attrs = append(attrs, obfuscate(MessageKeyKey, s, maxSize))
+	}
+	if s, ok := printable(value); ok {
+		attrs = append(attrs, obfuscate(MessagePayloadKey, s, maxSize))
+	}
+
+	// Headers set by the propagators only duplicate the trace context.
+	skip := make(map[string]struct{})
+	for _, f := range cfg.Propagators.Fields() {
+		skip[strings.ToLower(f)] = struct{}{}
+	}
+	collected := make(map[string]string)
+	for _, h := range headers {
+		if h == nil {
+			continue
+		}
+		if _, ok := skip[strings.ToLower(string(h.Key))]; ok {
+			continue
+		}
+		if s, ok := printable(h.Value); ok {
+			collected[string(h.Key)] = s
+		}
+	}
+	if len(collected) > 0 {
+		if b, err := json.Marshal(collected); err == nil {
+			attrs = append(attrs, obfuscate(MessageHeadersKey, string(b), maxSize))
+		}
+	}
+
+	return attrs
+}
+
+// printable reports whether b holds JSON or text, in which case it is
+// returned as a string. Binary payloads, such as Avro or Protobuf encoded
+// messages, are not captured.
+func printable(b []byte) (string, bool) {
+	if len(b) == 0 {
+		return "", false
+	}
+	if json.Valid(b) {
+		return string(b), true
+	}
+	if !utf8.Valid(b) {
+		return "", false
+	}
+	s := string(b)
+	for _, r := range s {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return "", false
+		}
+	}
+	return s, true
+}
+
+// obfuscate redacts the value, then truncates it to maxSize bytes.
+func obfuscate(key attribute.Key, value string, maxSize int) attribute.KeyValue {
+	attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: key, Value: attribute.StringValue(value)})
+	if s := attr.Value.AsString(); len(s) > maxSize {
+		cut := maxSize
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		attr.Value = attribute.StringValue(s[:cut])
+	}
+	return attr
+}
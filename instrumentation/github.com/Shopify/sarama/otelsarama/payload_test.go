@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsarama
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestPrintable(t *testing.T) {
+	testCases := []struct {
+		name    string
+		payload []byte
+		ok      bool
+	}{
+		{name: "empty", payload: nil, ok: false},
+		{name: "json", payload: []byte(`{"id":1}`), ok: true},
+		{name: "text", payload: []byte("order created\n"), ok: true},
+		{name: "binary", payload: []byte{0x00, 0x01, 0xff}, ok: false},
+		{name: "control characters", payload: []byte("a\x00b"), ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ok := printable(tc.payload)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestPayloadAttributes(t *testing.T) {
+	cfg := newConfig(
+		WithPropagators(propagation.TraceContext{}),
+		WithPayloadCapture(),
+		WithPayloadMaxSize(8),
+	)
+
+	msg := &sarama.ConsumerMessage{
+		Key:   []byte("key"),
+		Value: []byte(strings.Repeat("v", 32)),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("traceparent"), Value: []byte("00-00000000000000000000000000000001-0000000000000001-01")},
+			{Key: []byte("binary"), Value: []byte{0x00, 0xff}},
+		},
+	}
+
+	attrs := consumerPayloadAttributes(cfg, msg)
+	require.Len(t, attrs, 2)
+	assert.Equal(t, MessageKeyKey, attrs[0].Key)
+	assert.Equal(t, MessagePayloadKey, attrs[1].Key)
+	assert.LessOrEqual(t, len(attrs[1].Value.AsString()), 8)
+
+	msg.Headers = append(msg.Headers, &sarama.RecordHeader{Key: []byte("tenant"), Value: []byte("acme")})
+	attrs = consumerPayloadAttributes(cfg, msg)
+	require.Len(t, attrs, 3)
+	assert.Equal(t, MessageHeadersKey, attrs[2].Key)
+	assert.NotContains(t, attrs[2].Value.AsString(), "traceparent")
+}
+
+func TestCapturePayload(t *testing.T) {
+	assert.False(t, newConfig().capturePayload())
+	assert.True(t, newConfig(WithPayloadCapture()).capturePayload())
+
+	t.Setenv("HS_METADATA_ONLY", "true")
+	assert.False(t, newConfig(WithPayloadCapture()).capturePayload())
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 
@@ -34,13 +35,16 @@ type syncProducer struct {
 	sarama.SyncProducer
 	cfg          config
 	saramaConfig *sarama.Config
+	instruments  *instruments
 }
 
 // SendMessage calls sarama.SyncProducer.SendMessage and traces the request.
 func (p *syncProducer) SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
 	span := startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
+	start := time.Now()
 	partition, offset, err = p.SyncProducer.SendMessage(msg)
 	finishProducerSpan(span, partition, offset, err)
+	p.instruments.recordProduced(context.Background(), msg.Topic, msgPayloadSize(msg, p.saramaConfig.Version), start, err)
 	return partition, offset, err
 }
 
@@ -52,10 +56,27 @@ func (p *syncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
 	for i, msg := range msgs {
 		spans[i] = startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
 	}
+	start := time.Now()
 	err := p.SyncProducer.SendMessages(msgs)
 	for i, span := range spans {
 		finishProducerSpan(span, msgs[i].Partition, msgs[i].Offset, err)
 	}
+
+	// When only some messages failed, sarama reports which ones.
+	var failed map[*sarama.ProducerMessage]error
+	if errs, ok := err.(sarama.ProducerErrors); ok {
+		failed = make(map[*sarama.ProducerMessage]error, len(errs))
+		for _, e := range errs {
+			failed[e.Msg] = e.Err
+		}
+	}
+	for _, msg := range msgs {
+		msgErr := err
+		if failed != nil {
+			msgErr = failed[msg]
+		}
+		p.instruments.recordProduced(context.Background(), msg.Topic, msgPayloadSize(msg, p.saramaConfig.Version), start, msgErr)
+	}
 	return err
 }
 
@@ -71,6 +92,7 @@ func WrapSyncProducer(saramaConfig *sarama.Config, producer sarama.SyncProducer,
 		SyncProducer: producer,
 		cfg:          cfg,
 		saramaConfig: saramaConfig,
+		instruments:  newInstruments(cfg.MeterProvider, cfg.ConsumerGroup),
 	}
 }
 
@@ -119,6 +141,8 @@ func (p *asyncProducer) Close() error {
 type producerMessageContext struct {
 	span           trace.Span
 	metadataBackup interface{}
+	startTime      time.Time
+	size           int
 }
 
 // WrapAsyncProducer wraps a sarama.AsyncProducer so that all produced messages
@@ -126,12 +150,13 @@ type producerMessageContext struct {
 // or not successes will be returned.
 //
 // If `Return.Successes` is false, there is no way to know partition and offset of
-// the message.
+// the message, nor when it was acknowledged, so no metrics are recorded for it.
 func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts ...Option) sarama.AsyncProducer {
 	cfg := newConfig(opts...)
 	if saramaConfig == nil {
 		saramaConfig = sarama.NewConfig()
 	}
+	instruments := newInstruments(cfg.MeterProvider, cfg.ConsumerGroup)
 
 	wrapped := &asyncProducer{
 		AsyncProducer: p,
@@ -168,6 +193,8 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 				mc := producerMessageContext{
 					metadataBackup: msg.Metadata,
 					span:           span,
+					startTime:      time.Now(),
+					size:           msgPayloadSize(msg, saramaConfig.Version),
 				}
 
 				// Remember metadata using span ID as a cache key
@@ -205,6 +232,7 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 			if mc, ok := producerMessageContexts[key]; ok {
 				delete(producerMessageContexts, key)
 				finishProducerSpan(mc.span, msg.Partition, msg.Offset, nil)
+				instruments.recordProduced(context.Background(), msg.Topic, mc.size, mc.startTime, nil)
 				msg.Metadata = mc.metadataBackup // Restore message metadata
 			}
 			mtx.Unlock()
@@ -225,6 +253,7 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 			if mc, ok := producerMessageContexts[key]; ok {
 				delete(producerMessageContexts, key)
 				finishProducerSpan(mc.span, errMsg.Msg.Partition, errMsg.Msg.Offset, errMsg.Err)
+				instruments.recordProduced(context.Background(), errMsg.Msg.Topic, mc.size, mc.startTime, errMsg.Err)
 				errMsg.Msg.Metadata = mc.metadataBackup // Restore message metadata
 			}
 			mtx.Unlock()
@@ -291,6 +320,9 @@ func startProducerSpan(cfg config, version sarama.KafkaVersion, msg *sarama.Prod
 		semconv.MessagingMessagePayloadSizeBytesKey.Int(msgPayloadSize(msg, version)),
 		semconv.MessagingOperationKey.String("send"),
 	}
+	if cfg.capturePayload() {
+		attrs = append(attrs, producerPayloadAttributes(cfg, msg)...)
+	}
 	opts := []trace.SpanStartOption{
 		trace.WithAttributes(attrs...),
 		trace.WithSpanKind(trace.SpanKindProducer),
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStartBatch(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := propagation.TraceContext{}
+
+	var (
+		msgs     []*sarama.ConsumerMessage
+		producer []trace.SpanContext
+	)
+	for _, m := range []struct {
+		partition int32
+		offset    int64
+	}{{0, 12}, {0, 10}, {1, 3}} {
+		ctx, span := provider.Tracer("test").Start(context.Background(), "send")
+		msg := &sarama.ConsumerMessage{Topic: topic, Partition: m.partition, Offset: m.offset}
+		propagators.Inject(ctx, otelsarama.NewConsumerMessageCarrier(msg))
+		span.End()
+
+		msgs = append(msgs, msg)
+		producer = append(producer, span.SpanContext())
+	}
+	// Messages without a trace context are counted but not linked.
+	msgs = append(msgs, &sarama.ConsumerMessage{Topic: topic, Partition: 1, Offset: 4})
+	// Nil messages are neither counted nor linked.
+	msgs = append(msgs, nil)
+
+	_, span := otelsarama.StartBatch(context.Background(), msgs,
+		otelsarama.WithTracerProvider(provider),
+		otelsarama.WithPropagators(propagators),
+	)
+	span.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 4)
+	batch := spans[3]
+
+	assert.Equal(t, "test-topic process", batch.Name())
+	assert.Equal(t, trace.SpanKindConsumer, batch.SpanKind())
+	assert.False(t, batch.Parent().IsValid())
+	assert.Contains(t, batch.Attributes(), semconv.MessagingOperationProcess)
+	assert.Contains(t, batch.Attributes(), semconv.MessagingDestinationKey.String(topic))
+	assert.Contains(t, batch.Attributes(), otelsarama.BatchMessageCountKey.Int(4))
+	assert.Contains(t, batch.Attributes(), otelsarama.BatchOffsetsKey.StringSlice([]string{"test-topic/0:10-12", "test-topic/1:3-4"}))
+
+	require.Len(t, batch.Links(), 3)
+	for i, link := range batch.Links() {
+		assert.Equal(t, producer[i].SpanID(), link.SpanContext.SpanID())
+	}
+}
+
+func TestStartBatchMultipleTopics(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "worker")
+	_, span := otelsarama.StartBatch(ctx, []*sarama.ConsumerMessage{
+		{Topic: "orders", Partition: 0, Offset: 1},
+		{Topic: "payments", Partition: 2, Offset: 7},
+	}, otelsarama.WithTracerProvider(provider))
+	span.End()
+	parent.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	batch := spans[0]
+
+	assert.Equal(t, "batch process", batch.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), batch.Parent().SpanID())
+	assert.Contains(t, batch.Attributes(), otelsarama.BatchOffsetsKey.StringSlice([]string{"orders/0:1-1", "payments/2:7-7"}))
+	for _, attr := range batch.Attributes() {
+		assert.NotEqual(t, semconv.MessagingDestinationKey, attr.Key)
+	}
+}
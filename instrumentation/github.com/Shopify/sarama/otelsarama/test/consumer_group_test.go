@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+
+	generation int32
+	claims     map[string][]int32
+	marked     []*sarama.ConsumerMessage
+	commits    int
+}
+
+func (s *fakeSession) Claims() map[string][]int32 { return s.claims }
+func (s *fakeSession) MemberID() string           { return "member-1" }
+func (s *fakeSession) GenerationID() int32        { return s.generation }
+func (s *fakeSession) Context() context.Context   { return context.Background() }
+func (s *fakeSession) Commit()                    { s.commits++ }
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg)
+}
+
+// markingHandler marks and commits every message it is given in Setup.
+type markingHandler struct {
+	msg *sarama.ConsumerMessage
+}
+
+func (h markingHandler) Setup(session sarama.ConsumerGroupSession) error {
+	if h.msg != nil {
+		session.MarkMessage(h.msg, "")
+		session.Commit()
+	}
+	return nil
+}
+func (markingHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+func (markingHandler) ConsumeClaim(sarama.ConsumerGroupSession, sarama.ConsumerGroupClaim) error {
+	return nil
+}
+
+func TestWrapConsumerGroupHandlerSession(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	handler := otelsarama.WrapConsumerGroupHandler(markingHandler{}, otelsarama.WithTracerProvider(provider))
+
+	first := &fakeSession{generation: 1, claims: map[string][]int32{topic: {0, 1}}}
+	require.NoError(t, handler.Setup(first))
+	require.NoError(t, handler.Cleanup(first))
+
+	second := &fakeSession{generation: 2, claims: map[string][]int32{topic: {1, 2}}}
+	require.NoError(t, handler.Setup(second))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, "consumer group setup", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), otelsarama.ConsumerGroupGenerationIDKey.Int64(1))
+	assert.Contains(t, spans[0].Attributes(), otelsarama.ConsumerGroupMemberIDKey.String("member-1"))
+	assert.Contains(t, spans[0].Attributes(), otelsarama.ConsumerGroupClaimsKey.StringSlice([]string{"test-topic/0", "test-topic/1"}))
+	assert.Contains(t, spans[0].Attributes(), otelsarama.ConsumerGroupAssignedKey.StringSlice([]string{"test-topic/0", "test-topic/1"}))
+
+	assert.Equal(t, "consumer group cleanup", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), otelsarama.ConsumerGroupGenerationIDKey.Int64(1))
+
+	assert.Equal(t, "consumer group setup", spans[2].Name())
+	assert.Contains(t, spans[2].Attributes(), otelsarama.ConsumerGroupGenerationIDKey.Int64(2))
+	assert.Contains(t, spans[2].Attributes(), otelsarama.ConsumerGroupAssignedKey.StringSlice([]string{"test-topic/2"}))
+	assert.Contains(t, spans[2].Attributes(), otelsarama.ConsumerGroupRevokedKey.StringSlice([]string{"test-topic/0"}))
+}
+
+func TestWrapConsumerGroupHandlerCommitSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := propagation.TraceContext{}
+
+	// Message carrying the context of its receive span.
+	ctx, receive := provider.Tracer("test").Start(context.Background(), "receive")
+	msg := &sarama.ConsumerMessage{Topic: topic, Partition: 1, Offset: 5}
+	propagators.Inject(ctx, otelsarama.NewConsumerMessageCarrier(msg))
+	receive.End()
+
+	handler := otelsarama.WrapConsumerGroupHandler(markingHandler{msg: msg},
+		otelsarama.WithTracerProvider(provider),
+		otelsarama.WithPropagators(propagators),
+		otelsarama.WithCommitSpans(),
+	)
+
+	session := &fakeSession{generation: 1, claims: map[string][]int32{topic: {1}}}
+	require.NoError(t, handler.Setup(session))
+	assert.Equal(t, []*sarama.ConsumerMessage{msg}, session.marked)
+	assert.Equal(t, 1, session.commits)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 4)
+
+	mark, commit := spans[1], spans[2]
+	assert.Equal(t, "test-topic mark", mark.Name())
+	assert.Equal(t, receive.SpanContext().SpanID(), mark.Parent().SpanID())
+	assert.Equal(t, "consumer group commit", commit.Name())
+	assert.Equal(t, "consumer group setup", spans[3].Name())
+}
@@ -19,6 +19,7 @@ import (
 	"net/http"
 
 	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 
 	"github.com/helios/opentelemetry-go-contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/astaxie/beego/otelbeego/internal"
@@ -60,6 +61,30 @@ func defaultSpanNameFormatter(operation string, req *http.Request) string {
 	return req.Method
 }
 
+// routeHandler sets the route template found by Handler, if any, as the
+// route of the server span. When handler is a beego.ControllerRegister, it
+// also traces its filter stages and controllers, ending the spans left open
+// once handler returns, as beego skips the remaining stages on StopRun,
+// Abort or panics.
+func routeHandler(handler http.Handler) http.Handler {
+	handlers, ok := handler.(*beego.ControllerRegister)
+	if ok {
+		instrument(handlers)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if route, ok := ctx.Value(internal.CtxRouteTemplateKey).(string); ok {
+			trace.SpanFromContext(ctx).SetAttributes(semconv.HTTPRouteKey.String(route))
+		}
+		if handlers != nil {
+			s := &requestSpans{handlers: handlers, server: trace.SpanFromContext(ctx)}
+			req = s.startStage(req.WithContext(context.WithValue(ctx, internal.CtxRequestSpansKey, s)), beego.BeforeStatic)
+			defer s.end(req)
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
 // NewOTelBeegoMiddleWare creates a MiddleWare that provides OpenTelemetry
 // tracing and metrics to a Beego web app.
 // Parameter service should describe the name of the (virtual) server handling the request.
@@ -87,7 +112,7 @@ func NewOTelBeegoMiddleWare(service string, options ...Option) beego.MiddleWare
 	return func(handler http.Handler) http.Handler {
 		return &Handler{
 			otelhttp.NewHandler(
-				handler,
+				routeHandler(handler),
 				service,
 				httpOptions...,
 			),
@@ -139,11 +164,14 @@ func RenderBytes(c *beego.Controller) ([]byte, error) {
 	return bytes, err
 }
 
+// tracer returns the tracer of the provider of the span in ctx.
+func tracer(ctx context.Context) trace.Tracer {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer("go.opentelemetry.io/contrib/instrumentation/github/astaxie/beego/otelbeego")
+}
+
 func span(c *beego.Controller, spanName string) (context.Context, trace.Span) {
 	ctx := c.Ctx.Request.Context()
-	span := trace.SpanFromContext(ctx)
-	tracer := span.TracerProvider().Tracer("go.opentelemetry.io/contrib/instrumentation/github/astaxie/beego/otelbeego")
-	return tracer.Start(
+	return tracer(ctx).Start(
 		ctx,
 		spanName,
 		trace.WithAttributes(
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelbeego // import "go.opentelemetry.io/contrib/instrumentation/github.com/astaxie/beego/otelbeego"
+
+import (
+	"html"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/astaxie/beego"
+	beegoCtx "github.com/astaxie/beego/context"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// actionMethods are the controller methods invoked by beego for the actions
+// named after an HTTP method.
+var actionMethods = map[string]string{
+	http.MethodGet:     "Get",
+	http.MethodPost:    "Post",
+	http.MethodDelete:  "Delete",
+	http.MethodPut:     "Put",
+	http.MethodHead:    "Head",
+	http.MethodPatch:   "Patch",
+	http.MethodOptions: "Options",
+	http.MethodTrace:   "Trace",
+}
+
+// controllerRoute is a router of a beego controller.
+type controllerRoute struct {
+	controller string
+	// methods maps HTTP methods to the controller methods.
+	methods map[string]string
+}
+
+// routeKey identifies a router of a beego.ControllerRegister by HTTP method
+// and router pattern.
+type routeKey struct {
+	handlers *beego.ControllerRegister
+	method   string
+	pattern  string
+}
+
+// controllerRoutes caches the controller routers of beego.BeeApp, resolved
+// when first matched, so that routers added at any time are resolved. A nil
+// *controllerRoute records a router that does not run a controller.
+var controllerRoutes sync.Map
+
+// lookupRoute returns the controller router of beego.BeeApp matched for
+// requests using the HTTP method to the router pattern.
+func lookupRoute(method, pattern string) (*controllerRoute, bool) {
+	key := routeKey{handlers: beego.BeeApp.Handlers, method: method, pattern: pattern}
+	if r, ok := controllerRoutes.Load(key); ok {
+		route := r.(*controllerRoute)
+		return route, route != nil
+	}
+	route := findRoute(method, pattern)
+	controllerRoutes.Store(key, route)
+	return route, route != nil
+}
+
+// findRoute finds the controller router matched for requests using the HTTP
+// method to the router pattern in the router tree of beego.BeeApp, the only
+// one beego lists. It returns nil if the router does not run a controller.
+func findRoute(method, pattern string) *controllerRoute {
+	data, _ := beego.PrintTree()["Data"].(beego.M)
+	leaves, ok := data[method].(*[][]string)
+	if !ok {
+		return nil
+	}
+	for _, leaf := range *leaves {
+		// Leaves are the router pattern, the methods mapping and the
+		// controller type, only set for controller routers.
+		if len(leaf) != 3 || html.UnescapeString(leaf[0]) != pattern {
+			continue
+		}
+		if leaf[2] == "" {
+			return nil
+		}
+		controller := html.UnescapeString(leaf[2])
+		return &controllerRoute{
+			controller: controller[strings.LastIndex(controller, ".")+1:],
+			methods:    parseMethods(html.UnescapeString(leaf[1])),
+		}
+	}
+	return nil
+}
+
+// parseMethods parses a methods mapping formatted as "map[GET:Get POST:Post]".
+func parseMethods(s string) map[string]string {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "map["), "]")
+	methods := make(map[string]string)
+	for _, m := range strings.Fields(s) {
+		if parts := strings.SplitN(m, ":", 2); len(parts) == 2 {
+			methods[parts[0]] = parts[1]
+		}
+	}
+	return methods
+}
+
+// action returns the method of the controller invoked by beego for a
+// request using the HTTP method.
+func (route *controllerRoute) action(method string) string {
+	action, ok := route.methods[method]
+	if !ok {
+		if action, ok = route.methods["*"]; !ok {
+			action = method
+		}
+	}
+	if m, ok := actionMethods[action]; ok {
+		action = m
+	}
+	return action
+}
+
+// startController starts the span of the controller method invoked for the
+// request of c, named "Controller.Method", and returns the request with its
+// context.
+func (s *requestSpans) startController(c *beegoCtx.Context) *http.Request {
+	pattern, ok := c.Input.GetData("RouterPattern").(string)
+	if !ok || s.handlers != beego.BeeApp.Handlers {
+		return c.Request
+	}
+
+	// beego emulates PUT and DELETE requests with the _method parameter.
+	method := c.Request.Method
+	if method == http.MethodPost {
+		if m := c.Input.Query("_method"); m == http.MethodPut || m == http.MethodDelete {
+			method = m
+		}
+	}
+	route, ok := lookupRoute(method, pattern)
+	if !ok {
+		return c.Request
+	}
+	controller, action := route.controller, route.action(method)
+	return s.start(
+		c.Request,
+		controller+"."+action,
+		semconv.CodeNamespaceKey.String(controller),
+		semconv.CodeFunctionKey.String(action),
+	)
+}
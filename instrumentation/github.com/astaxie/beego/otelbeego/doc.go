@@ -13,4 +13,23 @@
 // limitations under the License.
 
 // Package otelbeego instruments the github.com/astaxie/beego package.
+//
+// NewOTelBeegoMiddleWare traces the requests served by a beego application,
+// naming server spans after the matched router pattern, which is also set as
+// their route. When applied to the beego.ControllerRegister of the
+// application, it also traces its filter stages and controllers, with spans
+// that follow each other as children of the server span:
+//
+//   - "beego.filter.<stage>" for each filter stage but after exec, from the
+//     end of the previous stage to the end of its filters. Filters added once the
+//     middleware is applied run after the span of their stage.
+//   - "Controller.Method" for the controller method invoked, from the end of
+//     the before exec filters to the end of the after exec filters: beego
+//     runs the filters of the application before those of the middleware,
+//     so the after exec filters are traced with the controller.
+//
+// Controllers are resolved from the router pattern matched for the request,
+// in the router tree of the beego application, when first matched.
+//
+// The context of these spans is set on the request of the beego context.
 package otelbeego // import "go.opentelemetry.io/contrib/instrumentation/github.com/astaxie/beego/otelbeego"
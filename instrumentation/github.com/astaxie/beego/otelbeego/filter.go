@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelbeego // import "go.opentelemetry.io/contrib/instrumentation/github.com/astaxie/beego/otelbeego"
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/astaxie/beego"
+	beegoCtx "github.com/astaxie/beego/context"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/astaxie/beego/otelbeego/internal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// filterStages are the names of the beego filter stages, indexed by position.
+var filterStages = map[int]string{
+	beego.BeforeStatic: "before_static",
+	beego.BeforeRouter: "before_router",
+	beego.BeforeExec:   "before_exec",
+	beego.AfterExec:    "after_exec",
+	beego.FinishRouter: "finish_router",
+}
+
+// registers holds the beego.ControllerRegisters whose filter stages are
+// traced.
+var registers sync.Map
+
+// instrument adds the filters delimiting the spans of the filter stages and
+// of the controller to handlers, once. beego appends filters to their stage,
+// so these run once the filters of the application ran.
+func instrument(handlers *beego.ControllerRegister) {
+	if _, loaded := registers.LoadOrStore(handlers, struct{}{}); loaded {
+		return
+	}
+	for pos := range filterStages {
+		// Run even when the response is written, and restore the params
+		// set by matching the pattern.
+		_ = handlers.InsertFilter("*", pos, stageFilter(pos), false, true)
+	}
+}
+
+// requestSpans are the spans of the filter stages and of the controller of
+// a request. They follow each other, so at most one is open at once.
+type requestSpans struct {
+	handlers *beego.ControllerRegister
+	server   trace.Span
+	span     trace.Span
+}
+
+// start starts the span name as a child of the server span, and returns
+// req with its context.
+func (s *requestSpans) start(req *http.Request, name string, attrs ...attribute.KeyValue) *http.Request {
+	ctx := trace.ContextWithSpan(req.Context(), s.server)
+	ctx, s.span = tracer(ctx).Start(ctx, name, trace.WithAttributes(attrs...))
+	return req.WithContext(ctx)
+}
+
+// startStage starts the span of the filter stage pos, and returns req with
+// its context.
+func (s *requestSpans) startStage(req *http.Request, pos int) *http.Request {
+	stage := filterStages[pos]
+	return s.start(req, internal.FilterSpanNamePrefix+stage, internal.FilterStageKey.String(stage))
+}
+
+// end ends the open span, if any, and returns req with the context of the
+// server span.
+func (s *requestSpans) end(req *http.Request) *http.Request {
+	if s.span == nil {
+		return req
+	}
+	s.span.End()
+	s.span = nil
+	return req.WithContext(trace.ContextWithSpan(req.Context(), s.server))
+}
+
+// stageFilter ends the span open at the end of the filter stage pos, and
+// starts the span of what beego runs next: the filters of the following
+// stage, or the controller after the before exec filters.
+func stageFilter(pos int) beego.FilterFunc {
+	return func(c *beegoCtx.Context) {
+		s, ok := c.Request.Context().Value(internal.CtxRequestSpansKey).(*requestSpans)
+		if !ok {
+			return
+		}
+		c.Request = s.end(c.Request)
+		switch pos {
+		case beego.BeforeExec:
+			c.Request = s.startController(c)
+		case beego.FinishRouter:
+		default:
+			c.Request = s.startStage(c.Request, pos+1)
+		}
+	}
+}
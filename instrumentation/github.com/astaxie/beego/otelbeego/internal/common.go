@@ -25,6 +25,9 @@ type ContextKey string
 const (
 	// CtxRouteTemplateKey is the context key used for a route template.
 	CtxRouteTemplateKey = ContextKey("x-opentelemetry-route-template")
+	// CtxRequestSpansKey is the context key used for the spans of the
+	// filter stages and of the controller of a request.
+	CtxRequestSpansKey = ContextKey("x-opentelemetry-request-spans")
 
 	// RenderTemplateSpanName is the span name for the beego.Controller.Render
 	// operation.
@@ -36,6 +39,12 @@ const (
 	// beego.Controller.RenderBytes operation.
 	RenderBytesSpanName = "beego.render.bytes"
 
+	// FilterSpanNamePrefix is the prefix of the span names of the beego
+	// filters, followed by the name of their stage.
+	FilterSpanNamePrefix = "beego.filter."
+
 	// TemplateKey is used to describe the beego template used.
 	TemplateKey = attribute.Key("go.template")
+	// FilterStageKey is used to describe the beego filter stage.
+	FilterStageKey = attribute.Key("beego.filter.stage")
 )
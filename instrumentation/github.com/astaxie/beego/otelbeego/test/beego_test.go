@@ -33,6 +33,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/astaxie/beego"
 	beegoCtx "github.com/astaxie/beego/context"
@@ -221,8 +222,10 @@ func TestStatic(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "<h1>Hello, world!</h1>", string(body))
 	spans := sr.Ended()
-	require.Len(t, spans, 1)
-	assertSpan(t, spans[0], tc)
+	require.Len(t, spans, 3) // The before static and before router filter spans, and the server span
+	assert.Equal(t, "beego.filter.before_static", spans[0].Name())
+	assert.Equal(t, "beego.filter.before_router", spans[1].Name())
+	assertSpan(t, spans[2], tc)
 }
 
 func TestRender(t *testing.T) {
@@ -266,12 +269,19 @@ func TestRender(t *testing.T) {
 	}
 
 	spans := sr.Ended()
-	require.Len(t, spans, 6) // 3 HTTP requests, each creating 2 spans
+	require.Len(t, spans, 21) // 3 HTTP requests, each creating 7 spans
 	for _, span := range spans {
 		switch span.Name() {
+		case "beego.filter.before_static",
+			"beego.filter.before_router",
+			"beego.filter.before_exec",
+			"beego.filter.finish_router":
 		case "/template/render":
 		case "/template/renderstring":
 		case "/template/renderbytes":
+		case "testController.TemplateRender":
+		case "testController.TemplateRenderString":
+		case "testController.TemplateRenderBytes":
 			continue
 		case internal.RenderTemplateSpanName,
 			internal.RenderStringSpanName,
@@ -283,6 +293,138 @@ func TestRender(t *testing.T) {
 	}
 }
 
+func TestRoute(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tracerProvider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+	addTestRoutes(t)
+	defer replaceBeego()
+
+	mw := otelbeego.NewOTelBeegoMiddleWare(middleWareName, otelbeego.WithTracerProvider(tracerProvider))
+	for _, path := range []string{"/1", "/api/v1/1"} {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		require.NoError(t, err)
+		mw(beego.BeeApp.Handlers).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	}
+
+	spans := sr.Ended()
+	require.Len(t, spans, 12) // 2 HTTP requests, each creating 6 spans
+	assert.Contains(t, spans[5].Attributes(), semconv.HTTPRouteKey.String("/:id"))
+	assert.Contains(t, spans[11].Attributes(), semconv.HTTPRouteKey.String("/api/v1/:id"))
+}
+
+type tracedController struct {
+	beego.Controller
+}
+
+func (c *tracedController) Get() {
+	c.Ctx.WriteString(defaultReply)
+}
+
+func (c *tracedController) Greet() {
+	ctx := c.Ctx.Request.Context()
+	oteltrace.SpanFromContext(ctx).AddEvent("greeting")
+	c.Ctx.WriteString(defaultReply)
+}
+
+func (c *tracedController) Stop() {
+	c.Ctx.WriteString(defaultReply)
+	c.StopRun()
+}
+
+func (c *tracedController) Crash() {
+	panic("crash")
+}
+
+func TestFilterAndControllerSpans(t *testing.T) {
+	defer replaceBeego()
+	sr := tracetest.NewSpanRecorder()
+	tracerProvider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+
+	beego.Router("/traced", &tracedController{})
+	beego.InsertFilter("/*", beego.BeforeRouter, func(ctx *beegoCtx.Context) {
+		oteltrace.SpanFromContext(ctx.Request.Context()).AddEvent("filtered")
+	})
+	beego.InsertFilter("/*", beego.FinishRouter, func(ctx *beegoCtx.Context) {}, false)
+
+	mw := otelbeego.NewOTelBeegoMiddleWare(middleWareName, otelbeego.WithTracerProvider(tracerProvider))
+	for _, path := range []string{"/traced", "/traced/greet"} {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		require.NoError(t, err)
+		mw(beego.BeeApp.Handlers).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+		// Routers added once requests are served are traced too.
+		beego.Router("/traced/greet", &tracedController{}, "get:Greet")
+	}
+
+	spans := sr.Ended()
+	require.Len(t, spans, 12)
+
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name()
+	}
+	assert.Equal(t, []string{
+		"beego.filter.before_static", "beego.filter.before_router", "beego.filter.before_exec",
+		"tracedController.Get", "beego.filter.finish_router", "/traced",
+		"beego.filter.before_static", "beego.filter.before_router", "beego.filter.before_exec",
+		"tracedController.Greet", "beego.filter.finish_router", "/traced/greet",
+	}, names)
+
+	server := spans[5]
+	for _, span := range spans[:5] {
+		assert.Equal(t, server.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+	require.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, "filtered", spans[1].Events()[0].Name)
+	assert.Contains(t, spans[1].Attributes(), internal.FilterStageKey.String("before_router"))
+
+	assert.Contains(t, spans[3].Attributes(), semconv.CodeNamespaceKey.String("tracedController"))
+	assert.Contains(t, spans[3].Attributes(), semconv.CodeFunctionKey.String("Get"))
+	require.Len(t, spans[9].Events(), 1)
+	assert.Equal(t, "greeting", spans[9].Events()[0].Name)
+}
+
+func TestControllerSpanEndedOnStop(t *testing.T) {
+	defer replaceBeego()
+	beego.Router("/traced/stop", &tracedController{}, "get:Stop")
+	beego.Router("/traced/crash", &tracedController{}, "get:Crash")
+	beego.InsertFilter("/*", beego.FinishRouter, func(ctx *beegoCtx.Context) {}, false)
+
+	for _, tc := range []struct {
+		path           string
+		status         int
+		controllerSpan string
+	}{
+		{path: "/traced/stop", status: http.StatusOK, controllerSpan: "tracedController.Stop"},
+		{path: "/traced/crash", status: http.StatusInternalServerError, controllerSpan: "tracedController.Crash"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tracerProvider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+			mw := otelbeego.NewOTelBeegoMiddleWare(middleWareName, otelbeego.WithTracerProvider(tracerProvider))
+
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "http://localhost"+tc.path, nil)
+			require.NoError(t, err)
+			mw(beego.BeeApp.Handlers).ServeHTTP(rr, req)
+			require.Equal(t, tc.status, rr.Result().StatusCode)
+
+			// beego skips the finish router filters, the controller span
+			// is ended by the middleware.
+			require.Len(t, sr.Started(), 5)
+			spans := sr.Ended()
+			require.Len(t, spans, 5)
+			assert.Equal(t, tc.controllerSpan, spans[3].Name())
+			assert.Equal(t, tc.path, spans[4].Name())
+			assert.Equal(t, spans[4].SpanContext().SpanID(), spans[3].Parent().SpanID())
+		})
+	}
+}
+
 // ------------------------------------------ Utilities
 
 func runTest(t *testing.T, tc *testCase, url string) {
@@ -320,8 +462,13 @@ func runTest(t *testing.T, tc *testCase, url string) {
 
 	spans := sr.Ended()
 	if tc.hasSpan {
-		require.Len(t, spans, 1)
-		assertSpan(t, spans[0], tc)
+		// The controller span, and filter spans, end before the server span.
+		require.NotEmpty(t, spans)
+		server := spans[len(spans)-1]
+		assertSpan(t, server, tc)
+		for _, span := range spans[:len(spans)-1] {
+			assert.Equal(t, server.SpanContext().SpanID(), span.Parent().SpanID())
+		}
 	} else {
 		require.Len(t, spans, 0)
 	}
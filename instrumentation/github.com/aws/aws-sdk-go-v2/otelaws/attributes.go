@@ -31,6 +31,8 @@ const (
 	RegionKey    attribute.Key = "aws.region"
 	ServiceKey   attribute.Key = "aws.service"
 	RequestIDKey attribute.Key = "aws.request_id"
+	AttemptKey   attribute.Key = "aws.attempt"
+	ErrorCodeKey attribute.Key = "aws.error_code"
 )
 
 var servicemap = map[string]AttributeSetter{
@@ -58,6 +60,16 @@ func RequestIDAttr(requestID string) attribute.KeyValue {
 	return RequestIDKey.String(requestID)
 }
 
+// AttemptAttr returns the AWS attempt number attribute.
+func AttemptAttr(attempt int) attribute.KeyValue {
+	return AttemptKey.Int(attempt)
+}
+
+// ErrorCodeAttr returns the AWS error code attribute.
+func ErrorCodeAttr(code string) attribute.KeyValue {
+	return ErrorCodeKey.String(code)
+}
+
 // DefaultAttributeSetter checks to see if there are service specific attributes available to set for the AWS service.
 // If there are service specific attributes available then they will be included.
 func DefaultAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
@@ -16,15 +16,20 @@ package otelaws // import "github.com/helios/opentelemetry-go-contrib/instrument
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	"go.opentelemetry.io/otel/trace"
@@ -36,13 +41,30 @@ const (
 
 type spanTimestampKey struct{}
 
+type operationKey struct{}
+
+// operation holds the state shared between an operation span and the spans of
+// its attempts.
+type operation struct {
+	span     trace.Span
+	attempts int
+}
+
+// requestIDHeader is the header AWS services use to return the request ID.
+const requestIDHeader = "X-Amzn-Requestid"
+
+// throttleErrors detects throttling errors the same way the SDK retryer does.
+var throttleErrors = retry.IsErrorThrottles(retry.DefaultThrottles)
+
 // AttributeSetter returns an array of KeyValue pairs, it can be used to set custom attributes.
 type AttributeSetter func(context.Context, middleware.InitializeInput) []attribute.KeyValue
 
 type otelMiddlewares struct {
 	tracer          trace.Tracer
+	instruments     *instruments
 	propagator      propagation.TextMapPropagator
 	attributeSetter []AttributeSetter
+	payloads        payloadCapture
 }
 
 func (m otelMiddlewares) initializeMiddlewareBefore(stack *middleware.Stack) error {
@@ -60,22 +82,33 @@ func (m otelMiddlewares) initializeMiddlewareAfter(stack *middleware.Stack) erro
 		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
 		out middleware.InitializeOutput, metadata middleware.Metadata, err error) {
 		serviceID := v2Middleware.GetServiceID(ctx)
+		operationName := v2Middleware.GetOperationName(ctx)
+		startTime := ctx.Value(spanTimestampKey{}).(time.Time)
 
 		attributes := []attribute.KeyValue{
 			ServiceAttr(serviceID),
 			RegionAttr(v2Middleware.GetRegion(ctx)),
-			OperationAttr(v2Middleware.GetOperationName(ctx)),
+			OperationAttr(operationName),
 		}
+		metricAttrs := attributes
 
 		ctx, span := m.tracer.Start(ctx, serviceID,
-			trace.WithTimestamp(ctx.Value(spanTimestampKey{}).(time.Time)),
+			trace.WithTimestamp(startTime),
 			trace.WithSpanKind(trace.SpanKindClient),
 		)
+		ctx = context.WithValue(ctx, operationKey{}, &operation{span: span})
 
 		for _, setter := range m.attributeSetter {
 			attributes = append(attributes, setter(ctx, in)...)
 		}
 
+		capturePayloads := m.payloads.enabled(serviceID, operationName)
+		if capturePayloads {
+			if attr, ok := m.payloads.attribute(RequestBodyKey, in.Parameters); ok {
+				attributes = append(attributes, attr)
+			}
+		}
+
 		span.SetAttributes(attributes...)
 
 		defer span.End()
@@ -84,8 +117,16 @@ func (m otelMiddlewares) initializeMiddlewareAfter(stack *middleware.Stack) erro
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
+		} else if capturePayloads {
+			if attr, ok := m.payloads.attribute(ResponseBodyKey, out.Result); ok {
+				span.SetAttributes(attr)
+			}
 		}
 
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedTime := float64(time.Since(startTime)) / float64(time.Millisecond)
+		m.instruments.duration.Record(ctx, elapsedTime, metricAttrs...)
+
 		return out, metadata, err
 	}),
 		middleware.After)
@@ -102,12 +143,22 @@ func (m otelMiddlewares) deserializeMiddleware(stack *middleware.Stack) error {
 			return out, metadata, err
 		}
 
-		span := trace.SpanFromContext(ctx)
-		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+		attributes := []attribute.KeyValue{semconv.HTTPStatusCodeKey.Int(resp.StatusCode)}
 
 		requestID, ok := v2Middleware.GetRequestIDMetadata(metadata)
-		if ok {
-			span.SetAttributes(RequestIDAttr(requestID))
+		if !ok {
+			requestID = resp.Header.Get(requestIDHeader)
+		}
+		if requestID != "" {
+			attributes = append(attributes, RequestIDAttr(requestID))
+		}
+
+		// The attempt span is the current span, the operation span reports
+		// the outcome of the last attempt.
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(attributes...)
+		if op, ok := ctx.Value(operationKey{}).(*operation); ok && op.span != span {
+			op.span.SetAttributes(attributes...)
 		}
 
 		return out, metadata, err
@@ -131,12 +182,73 @@ func (m otelMiddlewares) finalizeMiddleware(stack *middleware.Stack) error {
 		middleware.Before)
 }
 
+// attemptMiddleware starts a span for every attempt of an operation. It is
+// placed right after the SDK retry middleware so signing, transmission and
+// deserialization of each attempt are covered, while the time spent in retry
+// backoff shows up as gaps between attempt spans.
+func (m otelMiddlewares) attemptMiddleware(stack *middleware.Stack) error {
+	mw := middleware.FinalizeMiddlewareFunc("OTelAttemptMiddleware", func(
+		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+		out middleware.FinalizeOutput, metadata middleware.Metadata, err error) {
+		op, ok := ctx.Value(operationKey{}).(*operation)
+		if !ok {
+			return next.HandleFinalize(ctx, in)
+		}
+		op.attempts++
+
+		serviceID := v2Middleware.GetServiceID(ctx)
+		metricAttrs := []attribute.KeyValue{
+			ServiceAttr(serviceID),
+			RegionAttr(v2Middleware.GetRegion(ctx)),
+			OperationAttr(v2Middleware.GetOperationName(ctx)),
+		}
+
+		ctx, span := m.tracer.Start(ctx, serviceID+" attempt",
+			trace.WithSpanKind(trace.SpanKindInternal),
+			trace.WithAttributes(AttemptAttr(op.attempts)),
+		)
+		defer span.End()
+
+		// Inject the attempt span so that the service sees the attempt as parent.
+		if req, ok := in.Request.(*smithyhttp.Request); ok {
+			m.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+		}
+
+		out, metadata, err = next.HandleFinalize(ctx, in)
+		m.instruments.attempts.Add(ctx, 1, metricAttrs...)
+		if err == nil {
+			return out, metadata, err
+		}
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			span.SetAttributes(ErrorCodeAttr(apiErr.ErrorCode()))
+		}
+		if throttleErrors.IsErrorThrottle(err) == aws.TrueTernary {
+			m.instruments.throttles.Add(ctx, 1, metricAttrs...)
+		}
+
+		return out, metadata, err
+	})
+
+	// Clients without a retryer have no Retry middleware, in which case the
+	// single attempt is traced from the end of the finalize step.
+	if err := stack.Finalize.Insert(mw, "Retry", middleware.After); err == nil {
+		return nil
+	}
+	return stack.Finalize.Add(mw, middleware.After)
+}
+
 // AppendMiddlewares attaches OTel middlewares to the AWS Go SDK V2 for instrumentation.
 // OTel middlewares can be appended to either all aws clients or a specific operation.
 // Please see more details in https://aws.github.io/aws-sdk-go-v2/docs/middleware/
 func AppendMiddlewares(apiOptions *[]func(*middleware.Stack) error, opts ...Option) {
 	cfg := config{
 		TracerProvider:    otel.GetTracerProvider(),
+		MeterProvider:     global.MeterProvider(),
 		TextMapPropagator: otel.GetTextMapPropagator(),
 	}
 	for _, opt := range opts {
@@ -149,7 +261,9 @@ func AppendMiddlewares(apiOptions *[]func(*middleware.Stack) error, opts ...Opti
 
 	m := otelMiddlewares{tracer: cfg.TracerProvider.Tracer(tracerName,
 		trace.WithInstrumentationVersion(SemVersion())),
+		instruments:     newInstruments(cfg.MeterProvider),
 		propagator:      cfg.TextMapPropagator,
-		attributeSetter: cfg.AttributeSetter}
-	*apiOptions = append(*apiOptions, m.initializeMiddlewareBefore, m.initializeMiddlewareAfter, m.finalizeMiddleware, m.deserializeMiddleware)
+		attributeSetter: cfg.AttributeSetter,
+		payloads:        newPayloadCapture(cfg.PayloadOperations, cfg.PayloadMaxSize)}
+	*apiOptions = append(*apiOptions, m.initializeMiddlewareBefore, m.initializeMiddlewareAfter, m.finalizeMiddleware, m.attemptMiddleware, m.deserializeMiddleware)
 }
@@ -15,14 +15,18 @@
 package otelaws // import "github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 
 import (
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
 	TracerProvider    trace.TracerProvider
+	MeterProvider     metric.MeterProvider
 	TextMapPropagator propagation.TextMapPropagator
 	AttributeSetter   []AttributeSetter
+	PayloadOperations []string
+	PayloadMaxSize    int
 }
 
 // Option applies an option value.
@@ -48,6 +52,17 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating the
+// instruments that record operation latency, attempt counts and throttling
+// errors. If none is specified, the global MeterProvider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithTextMapPropagator specifies a Text Map Propagator to use when propagating context.
 // If none is specified, the global TextMapPropagator is used.
 func WithTextMapPropagator(propagator propagation.TextMapPropagator) Option {
@@ -65,3 +80,27 @@ func WithAttributeSetter(attributesetters ...AttributeSetter) Option {
 		cfg.AttributeSetter = append(cfg.AttributeSetter, attributesetters...)
 	})
 }
+
+// WithPayloadCapture enables capturing the serialized request parameters and
+// the deserialized response of the given operations, as the aws.request.body
+// and aws.response.body span attributes. Operations are identified by the
+// service ID and operation name, e.g. "DynamoDB.GetItem", while "SQS.*"
+// selects every operation of a service. Captured payloads are obfuscated and
+// nothing is captured when HS_METADATA_ONLY is set. Operations known to carry
+// secrets, such as "Secrets Manager.GetSecretValue", are never captured.
+func WithPayloadCapture(operations ...string) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.PayloadOperations = append(cfg.PayloadOperations, operations...)
+	})
+}
+
+// WithPayloadMaxSize specifies the maximum size, in bytes, of a captured
+// payload. Larger payloads are truncated. If none is specified,
+// DefaultPayloadMaxSize is used.
+func WithPayloadMaxSize(size int) Option {
+	return optionFunc(func(cfg *config) {
+		if size > 0 {
+			cfg.PayloadMaxSize = size
+		}
+	})
+}
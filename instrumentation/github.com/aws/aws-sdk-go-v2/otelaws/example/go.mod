@@ -36,6 +36,10 @@ require (
 	github.com/aws/smithy-go v1.13.5 // indirect
 	github.com/go-logr/logr v1.2.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/helios/go-sdk/data-utils v1.0.2 // indirect
 	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	github.com/ohler55/ojg v1.17.4 // indirect
+	go.opentelemetry.io/otel/metric v0.34.0 // indirect
+	golang.org/x/exp v0.0.0-20230203172020-98cc5a0785f9 // indirect
 	golang.org/x/sys v0.0.0-20220919091848-fb04ddd9f9c8 // indirect
 )
@@ -7,8 +7,10 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.18.0
 	github.com/aws/aws-sdk-go-v2/service/sqs v1.20.0
 	github.com/aws/smithy-go v1.13.5
+	github.com/helios/go-sdk/data-utils v1.0.2
 	github.com/stretchr/testify v1.8.1
 	go.opentelemetry.io/otel v1.11.2
+	go.opentelemetry.io/otel/metric v0.34.0
 	go.opentelemetry.io/otel/trace v1.11.2
 )
 
@@ -21,6 +23,8 @@ require (
 	github.com/go-logr/logr v1.2.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	github.com/ohler55/ojg v1.17.4 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/exp v0.0.0-20230203172020-98cc5a0785f9 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// AWS SDK client metrics.
+const (
+	ClientDuration  = "aws.client.duration"  // Operation duration including all attempts, milliseconds
+	ClientAttempts  = "aws.client.attempts"  // Number of attempts made for operations
+	ClientThrottles = "aws.client.throttles" // Number of attempts that failed with a throttling error
+)
+
+type instruments struct {
+	// duration is the end to end duration of an operation.
+	duration syncfloat64.Histogram
+
+	// attempts is the number of attempts made, retries included.
+	attempts syncint64.Counter
+
+	// throttles is the number of attempts rejected by throttling.
+	throttles syncint64.Counter
+}
+
+// newInstruments will create instruments using a meter
+// from the given provider p.
+func newInstruments(p metric.MeterProvider) *instruments {
+	meter := p.Meter(
+		tracerName,
+		metric.WithInstrumentationVersion(SemVersion()),
+	)
+	instruments := &instruments{}
+	var err error
+
+	if instruments.duration, err = meter.SyncFloat64().Histogram(
+		ClientDuration,
+		instrument.WithDescription("Duration of AWS SDK operations, retries included"),
+		instrument.WithUnit(unit.Milliseconds),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.attempts, err = meter.SyncInt64().Counter(
+		ClientAttempts,
+		instrument.WithDescription("Number of attempts made for AWS SDK operations"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.throttles, err = meter.SyncInt64().Counter(
+		ClientThrottles,
+		instrument.WithDescription("Number of AWS SDK attempts that failed with a throttling error"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return instruments
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"encoding/json"
+	"os"
+	"unicode/utf8"
+
+	datautils "github.com/helios/go-sdk/data-utils"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Payload attributes.
+const (
+	RequestBodyKey  attribute.Key = "aws.request.body"
+	ResponseBodyKey attribute.Key = "aws.response.body"
+)
+
+// DefaultPayloadMaxSize is the maximum size, in bytes, of a captured payload
+// when no size is configured with WithPayloadMaxSize.
+const DefaultPayloadMaxSize = 8 * 1024
+
+// sensitiveOperations are never captured, whatever the configuration, as
+// their parameters or results carry secrets or credentials.
+var sensitiveOperations = map[string]struct{}{
+	"Secrets Manager.GetSecretValue":              {},
+	"Secrets Manager.PutSecretValue":              {},
+	"Secrets Manager.CreateSecret":                {},
+	"Secrets Manager.UpdateSecret":                {},
+	"Secrets Manager.BatchGetSecretValue":         {},
+	"SSM.GetParameter":                            {},
+	"SSM.GetParameters":                           {},
+	"SSM.GetParametersByPath":                     {},
+	"SSM.GetParameterHistory":                     {},
+	"SSM.PutParameter":                            {},
+	"KMS.Decrypt":                                 {},
+	"KMS.Encrypt":                                 {},
+	"KMS.GenerateDataKey":                         {},
+	"KMS.GenerateDataKeyPair":                     {},
+	"KMS.GenerateRandom":                          {},
+	"STS.AssumeRole":                              {},
+	"STS.AssumeRoleWithSAML":                      {},
+	"STS.AssumeRoleWithWebIdentity":               {},
+	"STS.GetFederationToken":                      {},
+	"STS.GetSessionToken":                         {},
+	"IAM.CreateAccessKey":                         {},
+	"IAM.CreateLoginProfile":                      {},
+	"IAM.UpdateLoginProfile":                      {},
+	"Cognito Identity.GetCredentialsForIdentity":  {},
+	"Cognito Identity Provider.InitiateAuth":      {},
+	"Cognito Identity Provider.AdminInitiateAuth": {},
+	"Cognito Identity Provider.SignUp":            {},
+	"ECR.GetAuthorizationToken":                   {},
+}
+
+// payloadCapture decides which operations have their payloads captured and
+// converts those payloads to span attributes.
+type payloadCapture struct {
+	operations map[string]struct{}
+	maxSize    int
+}
+
+func newPayloadCapture(operations []string, maxSize int) payloadCapture {
+	p := payloadCapture{maxSize: maxSize}
+	if len(operations) > 0 {
+		p.operations = make(map[string]struct{}, len(operations))
+		for _, op := range operations {
+			p.operations[op] = struct{}{}
+		}
+	}
+	return p
+}
+
+func operationID(serviceID, operation string) string {
+	return serviceID + "." + operation
+}
+
+// enabled reports whether payloads of the operation should be captured.
+// Capture is disabled altogether when HS_METADATA_ONLY is set.
+func (p payloadCapture) enabled(serviceID, operation string) bool {
+	if len(p.operations) == 0 || os.Getenv("HS_METADATA_ONLY") == "true" {
+		return false
+	}
+
+	id := operationID(serviceID, operation)
+	if _, ok := sensitiveOperations[id]; ok {
+		return false
+	}
+	if _, ok := p.operations[id]; ok {
+		return true
+	}
+	_, ok := p.operations[operationID(serviceID, "*")]
+	return ok
+}
+
+// attribute serializes v to JSON and returns it as an obfuscated attribute
+// truncated to the configured maximum size. False is returned if there is
+// nothing to capture.
+func (p payloadCapture) attribute(key attribute.Key, v interface{}) (attribute.KeyValue, bool) {
+	if v == nil {
+		return attribute.KeyValue{}, false
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil || len(payload) == 0 || string(payload) == "null" || string(payload) == "{}" {
+		return attribute.KeyValue{}, false
+	}
+
+	// Obfuscate before truncating so that redaction rules see the whole document.
+	attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: key, Value: attribute.StringValue(string(payload))})
+	maxSize := p.maxSize
+	if maxSize <= 0 {
+		maxSize = DefaultPayloadMaxSize
+	}
+	if s := attr.Value.AsString(); len(s) > maxSize {
+		attr.Value = attribute.StringValue(truncate(s, maxSize))
+	}
+
+	return attr, true
+}
+
+// truncate cuts s to at most maxSize bytes on a UTF-8 boundary.
+func truncate(s string, maxSize int) string {
+	if len(s) <= maxSize {
+		return s
+	}
+	cut := maxSize
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
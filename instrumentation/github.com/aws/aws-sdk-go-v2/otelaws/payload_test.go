@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadCaptureEnabled(t *testing.T) {
+	p := newPayloadCapture([]string{"DynamoDB.GetItem", "SQS.*", "Secrets Manager.*"}, 0)
+
+	assert.True(t, p.enabled("DynamoDB", "GetItem"))
+	assert.False(t, p.enabled("DynamoDB", "PutItem"))
+	assert.True(t, p.enabled("SQS", "SendMessage"))
+	assert.True(t, p.enabled("Secrets Manager", "ListSecrets"))
+	assert.False(t, p.enabled("Secrets Manager", "GetSecretValue"))
+
+	t.Setenv("HS_METADATA_ONLY", "true")
+	assert.False(t, p.enabled("DynamoDB", "GetItem"))
+}
+
+func TestPayloadCaptureDisabledByDefault(t *testing.T) {
+	p := newPayloadCapture(nil, 0)
+
+	assert.False(t, p.enabled("DynamoDB", "GetItem"))
+}
+
+func TestPayloadCaptureAttribute(t *testing.T) {
+	p := newPayloadCapture([]string{"SQS.*"}, 16)
+
+	_, ok := p.attribute(RequestBodyKey, nil)
+	assert.False(t, ok)
+
+	_, ok = p.attribute(RequestBodyKey, struct{}{})
+	assert.False(t, ok)
+
+	attr, ok := p.attribute(RequestBodyKey, struct{ MessageBody string }{strings.Repeat("a", 64)})
+	assert.True(t, ok)
+	assert.Equal(t, RequestBodyKey, attr.Key)
+	assert.LessOrEqual(t, len(attr.Value.AsString()), 16)
+}
+
+func TestPayloadCaptureAttributeRuneBoundary(t *testing.T) {
+	p := newPayloadCapture([]string{"SQS.*"}, 16)
+
+	attr, ok := p.attribute(RequestBodyKey, struct{ MessageBody string }{strings.Repeat("é", 32)})
+	assert.True(t, ok)
+	assert.LessOrEqual(t, len(attr.Value.AsString()), 16)
+	assert.True(t, utf8.ValidString(attr.Value.AsString()))
+}
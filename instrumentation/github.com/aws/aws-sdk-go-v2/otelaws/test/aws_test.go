@@ -19,8 +19,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +31,8 @@ import (
 	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
@@ -135,8 +139,14 @@ func TestAppendMiddlewares(t *testing.T) {
 			}
 
 			spans := sr.Ended()
-			require.Len(t, spans, 1)
-			span := spans[0]
+			require.Len(t, spans, 2)
+			attempt, span := spans[0], spans[1]
+
+			assert.Equal(t, "Route 53 attempt", attempt.Name())
+			assert.Equal(t, span.SpanContext().SpanID(), attempt.Parent().SpanID())
+			assert.Equal(t, c.expectedError, attempt.Status().Code)
+			assert.Contains(t, attempt.Attributes(), attribute.Int("aws.attempt", 1))
+			assert.Contains(t, attempt.Attributes(), attribute.Int("http.status_code", c.expectedStatusCode))
 
 			assert.Equal(t, "Route 53", span.Name())
 			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
@@ -154,3 +164,107 @@ func TestAppendMiddlewares(t *testing.T) {
 		srv.Close()
 	}
 }
+
+func TestAppendMiddlewaresRetries(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`<?xml version="1.0"?>
+		<ErrorResponse xmlns="http://route53.amazonaws.com/doc/2016-09-07/">
+		  <Error>
+		    <Type>Sender</Type>
+		    <Code>Throttling</Code>
+		    <Message>Rate exceeded</Message>
+		  </Error>
+		  <RequestId>throttled-request</RequestId>
+		</ErrorResponse>`))
+				return
+			}
+			w.Header().Set("x-amzn-RequestId", "successful-request")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<ChangeResourceRecordSetsResponse>
+			<ChangeInfo>
+				<Comment>mockComment</Comment>
+				<Id>mockID</Id>
+			</ChangeInfo>
+		</ChangeResourceRecordSetsResponse>`))
+		}))
+	defer srv.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	svc := route53.NewFromConfig(aws.Config{
+		Region: "us-east-1",
+		EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(
+			func(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+				return aws.Endpoint{
+					URL:         srv.URL,
+					SigningName: "route53",
+				}, nil
+			},
+		),
+		Retryer: func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+					return 0, nil
+				})
+			})
+		},
+	})
+	_, err := svc.ChangeResourceRecordSets(context.Background(), &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{},
+			Comment: aws.String("mock"),
+		},
+		HostedZoneId: aws.String("zone"),
+	}, func(options *route53.Options) {
+		otelaws.AppendMiddlewares(&options.APIOptions,
+			otelaws.WithTracerProvider(provider),
+			otelaws.WithMeterProvider(meterProvider))
+	})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	first, second, span := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, "Route 53", span.Name())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusOK))
+	assert.Contains(t, span.Attributes(), attribute.String("aws.request_id", "successful-request"))
+
+	assert.Equal(t, span.SpanContext().SpanID(), first.Parent().SpanID())
+	assert.Equal(t, codes.Error, first.Status().Code)
+	assert.Contains(t, first.Attributes(), attribute.Int("aws.attempt", 1))
+	assert.Contains(t, first.Attributes(), attribute.String("aws.error_code", "Throttling"))
+	assert.Contains(t, first.Attributes(), attribute.String("aws.request_id", "throttled-request"))
+
+	assert.Equal(t, span.SpanContext().SpanID(), second.Parent().SpanID())
+	assert.Equal(t, codes.Unset, second.Status().Code)
+	assert.Contains(t, second.Attributes(), attribute.Int("aws.attempt", 2))
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	sums := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			require.Len(t, data.DataPoints, 1)
+			sums[m.Name] = data.DataPoints[0].Value
+		case metricdata.Histogram:
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, uint64(1), data.DataPoints[0].Count)
+		}
+	}
+	assert.Equal(t, int64(2), sums[otelaws.ClientAttempts])
+	assert.Equal(t, int64(1), sums[otelaws.ClientThrottles])
+}
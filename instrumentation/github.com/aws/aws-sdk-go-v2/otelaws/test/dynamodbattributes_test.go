@@ -90,8 +90,8 @@ func TestDynamodbTags(t *testing.T) {
 		}
 
 		spans := sr.Ended()
-		require.Len(t, spans, 1)
-		span := spans[0]
+		require.Len(t, spans, 2)
+		span := spans[1]
 
 		assert.Equal(t, "DynamoDB", span.Name())
 		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
@@ -177,8 +177,8 @@ func TestDynamodbTagsCustomSetter(t *testing.T) {
 		}
 
 		spans := sr.Ended()
-		require.Len(t, spans, 1)
-		span := spans[0]
+		require.Len(t, spans, 2)
+		span := spans[1]
 
 		assert.Equal(t, "DynamoDB", span.Name())
 		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
@@ -15,11 +15,14 @@
 package otelmemcache // import "go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache"
 
 import (
+	"go.opentelemetry.io/otel/metric"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
 	tracerProvider oteltrace.TracerProvider
+	meterProvider  metric.MeterProvider
+	hashKeys       bool
 }
 
 // Option is used to configure the client.
@@ -42,3 +45,22 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 		}
 	})
 }
+
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.meterProvider = provider
+		}
+	})
+}
+
+// WithHashedKeys specifies that item keys are recorded on spans as the hex
+// encoded SHA-256 hash of the key instead of the raw key, for keys that
+// contain sensitive data.
+func WithHashedKeys() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.hashKeys = true
+	})
+}
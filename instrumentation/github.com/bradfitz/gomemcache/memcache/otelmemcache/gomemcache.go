@@ -16,6 +16,10 @@ package otelmemcache // import "go.opentelemetry.io/contrib/instrumentation/gith
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
 
 	"github.com/bradfitz/gomemcache/memcache"
 
@@ -23,6 +27,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/global"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -33,8 +38,10 @@ const (
 // Client is a wrapper around *memcache.Client.
 type Client struct {
 	*memcache.Client
-	tracer oteltrace.Tracer
-	ctx    context.Context
+	tracer      oteltrace.Tracer
+	instruments *instruments
+	hashKeys    bool
+	ctx         context.Context
 }
 
 // NewClientWithTracing wraps the provided memcache client to allow
@@ -46,6 +53,10 @@ type Client struct {
 // executes the operation and ends the span (additionally also sets a status
 // error code and message, if an error occurs). Optionally, client context can
 // be set before an operation with the WithContext method.
+//
+// The duration of every client operation, and the number of keys found and
+// not found by get operations, are recorded with the meter provider set with
+// WithMeterProvider, otherwise the registered global meter provider is used.
 func NewClientWithTracing(client *memcache.Client, opts ...Option) *Client {
 	cfg := &config{}
 	for _, o := range opts {
@@ -55,14 +66,19 @@ func NewClientWithTracing(client *memcache.Client, opts ...Option) *Client {
 	if cfg.tracerProvider == nil {
 		cfg.tracerProvider = otel.GetTracerProvider()
 	}
+	if cfg.meterProvider == nil {
+		cfg.meterProvider = global.MeterProvider()
+	}
 
 	return &Client{
-		client,
-		cfg.tracerProvider.Tracer(
+		Client: client,
+		tracer: cfg.tracerProvider.Tracer(
 			tracerName,
 			oteltrace.WithInstrumentationVersion(SemVersion()),
 		),
-		context.Background(),
+		instruments: newInstruments(cfg.meterProvider),
+		hashKeys:    cfg.hashKeys,
+		ctx:         context.Background(),
 	}
 }
 
@@ -75,14 +91,34 @@ func (c *Client) attrsByOperationAndItemKey(operation internal.Operation, key ..
 	}
 
 	if len(key) > 0 {
+		if c.hashKeys {
+			hashed := make([]string, len(key))
+			for i, k := range key {
+				hashed[i] = hashKey(k)
+			}
+			key = hashed
+		}
 		attributes = append(attributes, internal.MemcacheDBItemKeys(key...))
 	}
 
 	return attributes
 }
 
+// hashKey returns the hex encoded SHA-256 hash of key.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// operation is a traced client operation.
+type operation struct {
+	name  internal.Operation
+	span  oteltrace.Span
+	start time.Time
+}
+
 // Starts span with appropriate span kind and attributes.
-func (c *Client) startSpan(operationName internal.Operation, itemKey ...string) oteltrace.Span {
+func (c *Client) startSpan(operationName internal.Operation, itemKey ...string) *operation {
 	opts := []oteltrace.SpanStartOption{
 		// for database client calls, always use CLIENT span kind
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
@@ -97,40 +133,72 @@ func (c *Client) startSpan(operationName internal.Operation, itemKey ...string)
 		opts...,
 	)
 
-	return span
+	return &operation{
+		name:  operationName,
+		span:  span,
+		start: time.Now(),
+	}
 }
 
-// Ends span and, if applicable, sets error status.
-func endSpan(s oteltrace.Span, err error) {
+// Ends span and, if applicable, sets error status. The duration of the
+// operation is recorded.
+func (c *Client) endSpan(op *operation, err error) {
 	if err != nil {
-		s.SetStatus(codes.Error, err.Error())
+		op.span.SetStatus(codes.Error, err.Error())
+	}
+	op.span.End()
+
+	// Use floating point division here for higher precision (instead of Millisecond method).
+	elapsed := float64(time.Since(op.start)) / float64(time.Millisecond)
+	c.instruments.duration.Record(c.ctx, elapsed, c.metricAttrs(op.name)...)
+}
+
+func (c *Client) metricAttrs(operation internal.Operation) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		internal.MemcacheDBSystem(),
+		internal.MemcacheDBOperation(operation),
+	}
+}
+
+// recordLookup records the number of keys found and not found by a get
+// operation.
+func (c *Client) recordLookup(op *operation, hits, misses int) {
+	attrs := c.metricAttrs(op.name)
+	if hits > 0 {
+		c.instruments.hits.Add(c.ctx, int64(hits), attrs...)
+	}
+	if misses > 0 {
+		c.instruments.misses.Add(c.ctx, int64(misses), attrs...)
 	}
-	s.End()
 }
 
 // WithContext retruns a copy of the client with provided context.
 func (c *Client) WithContext(ctx context.Context) *Client {
 	cc := c.Client
 	return &Client{
-		Client: cc,
-		tracer: c.tracer,
-		ctx:    ctx,
+		Client:      cc,
+		tracer:      c.tracer,
+		instruments: c.instruments,
+		hashKeys:    c.hashKeys,
+		ctx:         ctx,
 	}
 }
 
 // Add invokes the add operation and traces it.
 func (c *Client) Add(item *memcache.Item) error {
 	s := c.startSpan(internal.OperationAdd, item.Key)
+	s.span.SetAttributes(internal.MemcacheDBItemSize(len(item.Value)))
 	err := c.Client.Add(item)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
 // CompareAndSwap invokes the compare-and-swap operation and traces it.
 func (c *Client) CompareAndSwap(item *memcache.Item) error {
 	s := c.startSpan(internal.OperationCompareAndSwap, item.Key)
+	s.span.SetAttributes(internal.MemcacheDBItemSize(len(item.Value)))
 	err := c.Client.CompareAndSwap(item)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -138,7 +206,7 @@ func (c *Client) CompareAndSwap(item *memcache.Item) error {
 func (c *Client) Decrement(key string, delta uint64) (uint64, error) {
 	s := c.startSpan(internal.OperationDecrement, key)
 	newValue, err := c.Client.Decrement(key, delta)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return newValue, err
 }
 
@@ -146,7 +214,7 @@ func (c *Client) Decrement(key string, delta uint64) (uint64, error) {
 func (c *Client) Delete(key string) error {
 	s := c.startSpan(internal.OperationDelete, key)
 	err := c.Client.Delete(key)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -154,7 +222,7 @@ func (c *Client) Delete(key string) error {
 func (c *Client) DeleteAll() error {
 	s := c.startSpan(internal.OperationDeleteAll)
 	err := c.Client.DeleteAll()
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -162,23 +230,53 @@ func (c *Client) DeleteAll() error {
 func (c *Client) FlushAll() error {
 	s := c.startSpan(internal.OperationFlushAll)
 	err := c.Client.FlushAll()
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
-// Get invokes the get operation and traces it.
+// Get invokes the get operation and traces it. The span records whether the
+// key was found and the size of the returned item.
 func (c *Client) Get(key string) (*memcache.Item, error) {
 	s := c.startSpan(internal.OperationGet, key)
 	item, err := c.Client.Get(key)
-	endSpan(s, err)
+	switch {
+	case err == nil:
+		s.span.SetAttributes(
+			internal.MemcacheCacheHit(true),
+			internal.MemcacheDBItemSize(len(item.Value)),
+		)
+		c.recordLookup(s, 1, 0)
+	case errors.Is(err, memcache.ErrCacheMiss):
+		// A miss is an expected outcome of a lookup, not a failure.
+		s.span.SetAttributes(internal.MemcacheCacheHit(false))
+		c.recordLookup(s, 0, 1)
+		c.endSpan(s, nil)
+		return item, err
+	}
+	c.endSpan(s, err)
 	return item, err
 }
 
-// GetMulti invokes the get operation for multiple keys and traces it.
+// GetMulti invokes the get operation for multiple keys and traces it. The
+// span records the number of keys found and not found, and the total size of
+// the returned items.
 func (c *Client) GetMulti(keys []string) (map[string]*memcache.Item, error) {
 	s := c.startSpan(internal.OperationGet, keys...)
 	items, err := c.Client.GetMulti(keys)
-	endSpan(s, err)
+	if err == nil {
+		size := 0
+		for _, item := range items {
+			size += len(item.Value)
+		}
+		hits, misses := len(items), len(keys)-len(items)
+		s.span.SetAttributes(
+			internal.MemcacheHitCount(hits),
+			internal.MemcacheMissCount(misses),
+			internal.MemcacheDBItemSize(size),
+		)
+		c.recordLookup(s, hits, misses)
+	}
+	c.endSpan(s, err)
 	return items, err
 }
 
@@ -186,7 +284,7 @@ func (c *Client) GetMulti(keys []string) (map[string]*memcache.Item, error) {
 func (c *Client) Increment(key string, delta uint64) (uint64, error) {
 	s := c.startSpan(internal.OperationIncrement, key)
 	newValue, err := c.Client.Increment(key, delta)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return newValue, err
 }
 
@@ -194,23 +292,25 @@ func (c *Client) Increment(key string, delta uint64) (uint64, error) {
 func (c *Client) Ping() error {
 	s := c.startSpan(internal.OperationPing)
 	err := c.Client.Ping()
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
 // Replace invokes the replace operation and traces it.
 func (c *Client) Replace(item *memcache.Item) error {
 	s := c.startSpan(internal.OperationReplace, item.Key)
+	s.span.SetAttributes(internal.MemcacheDBItemSize(len(item.Value)))
 	err := c.Client.Replace(item)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
 // Set invokes the set operation and traces it.
 func (c *Client) Set(item *memcache.Item) error {
 	s := c.startSpan(internal.OperationSet, item.Key)
+	s.span.SetAttributes(internal.MemcacheDBItemSize(len(item.Value)))
 	err := c.Client.Set(item)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
 
@@ -218,6 +318,6 @@ func (c *Client) Set(item *memcache.Item) error {
 func (c *Client) Touch(key string, seconds int32) error {
 	s := c.startSpan(internal.OperationTouch, key)
 	err := c.Client.Touch(key, seconds)
-	endSpan(s, err)
+	c.endSpan(s, err)
 	return err
 }
@@ -15,10 +15,13 @@
 package otelmemcache
 
 import (
+	"context"
 	"testing"
 
 	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache/internal"
 )
 
 func TestNewClientWithTracing(t *testing.T) {
@@ -28,4 +31,16 @@ func TestNewClientWithTracing(t *testing.T) {
 
 	assert.NotNil(t, c.Client)
 	assert.NotNil(t, c.tracer)
+	assert.NotNil(t, c.instruments)
+}
+
+func TestHashedKeys(t *testing.T) {
+	c := NewClientWithTracing(memcache.New(), WithHashedKeys())
+
+	attrs := c.attrsByOperationAndItemKey(internal.OperationGet, "user:42")
+	assert.Contains(t, attrs, internal.MemcacheDBItemKeys(hashKey("user:42")))
+	assert.Len(t, hashKey("user:42"), 64)
+
+	attrs = c.WithContext(context.Background()).attrsByOperationAndItemKey(internal.OperationGet, "a", "b")
+	assert.Contains(t, attrs, internal.MemcacheDBItemKeys(hashKey("a"), hashKey("b")))
 }
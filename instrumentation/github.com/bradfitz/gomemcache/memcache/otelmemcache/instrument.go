@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmemcache // import "go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// Memcached metrics. No hit ratio is recorded: derive it from the hit and
+// miss counters, as hits / (hits + misses).
+const (
+	OperationDuration = "db.memcached.operation.duration" // Duration of client operations, milliseconds
+	CacheHits         = "db.memcached.hits"               // Number of keys found by get operations
+	CacheMisses       = "db.memcached.misses"             // Number of keys not found by get operations
+)
+
+type instruments struct {
+	// duration is the time taken by client operations.
+	duration syncfloat64.Histogram
+
+	// hits is the number of keys found by get operations.
+	hits syncint64.Counter
+
+	// misses is the number of keys not found by get operations.
+	misses syncint64.Counter
+}
+
+// newInstruments will create instruments using a meter
+// from the given provider p.
+func newInstruments(p metric.MeterProvider) *instruments {
+	meter := p.Meter(
+		tracerName,
+		metric.WithInstrumentationVersion(SemVersion()),
+	)
+	instruments := &instruments{}
+	var err error
+
+	if instruments.duration, err = meter.SyncFloat64().Histogram(
+		OperationDuration,
+		instrument.WithDescription("Duration of memcached client operations"),
+		instrument.WithUnit(unit.Milliseconds),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.hits, err = meter.SyncInt64().Counter(
+		CacheHits,
+		instrument.WithDescription("Number of keys found by get operations"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.misses, err = meter.SyncInt64().Counter(
+		CacheMisses,
+		instrument.WithDescription("Number of keys not found by get operations"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return instruments
+}
@@ -38,7 +38,11 @@ const (
 
 	MamcacheDBSystemValue = "memcached"
 
-	MemcacheDBItemKeyName attribute.Key = "db.memcached.item"
+	MemcacheDBItemKeyName  attribute.Key = "db.memcached.item"
+	MemcacheDBItemSizeName attribute.Key = "db.memcached.item.size"
+	MemcacheCacheHitName   attribute.Key = "cache.hit"
+	MemcacheHitCountName   attribute.Key = "cache.hit_count"
+	MemcacheMissCountName  attribute.Key = "cache.miss_count"
 )
 
 func MemcacheDBSystem() attribute.KeyValue {
@@ -56,3 +60,19 @@ func MemcacheDBItemKeys(itemKeys ...string) attribute.KeyValue {
 
 	return MemcacheDBItemKeyName.String(itemKeys[0])
 }
+
+func MemcacheDBItemSize(size int) attribute.KeyValue {
+	return MemcacheDBItemSizeName.Int(size)
+}
+
+func MemcacheCacheHit(hit bool) attribute.KeyValue {
+	return MemcacheCacheHitName.Bool(hit)
+}
+
+func MemcacheHitCount(count int) attribute.KeyValue {
+	return MemcacheHitCountName.Int(count)
+}
+
+func MemcacheMissCount(count int) attribute.KeyValue {
+	return MemcacheMissCountName.Int(count)
+}
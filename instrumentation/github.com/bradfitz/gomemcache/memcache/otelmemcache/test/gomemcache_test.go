@@ -50,15 +50,16 @@ func TestOperation(t *testing.T) {
 	assert.Len(t, spans, 1)
 	assert.Equal(t, oteltrace.SpanKindClient, spans[0].SpanKind())
 	assert.Equal(t, string(internal.OperationAdd), spans[0].Name())
-	assert.Len(t, spans[0].Attributes(), 3)
+	assert.Len(t, spans[0].Attributes(), 4)
 
 	attrs := spans[0].Attributes()
 	assert.Contains(t, attrs, internal.MemcacheDBSystem())
 	assert.Contains(t, attrs, internal.MemcacheDBOperation(internal.OperationAdd))
 	assert.Contains(t, attrs, internal.MemcacheDBItemKeyName.String(mi.Key))
+	assert.Contains(t, attrs, internal.MemcacheDBItemSize(len(mi.Value)))
 }
 
-func TestOperationWithCacheMissError(t *testing.T) {
+func TestOperationWithCacheMiss(t *testing.T) {
 	key := "foo"
 	c, sr := initClientWithSpanRecorder(t)
 
@@ -69,15 +70,32 @@ func TestOperationWithCacheMissError(t *testing.T) {
 	assert.Len(t, spans, 1)
 	assert.Equal(t, oteltrace.SpanKindClient, spans[0].SpanKind())
 	assert.Equal(t, string(internal.OperationGet), spans[0].Name())
-	assert.Len(t, spans[0].Attributes(), 3)
+	assert.Len(t, spans[0].Attributes(), 4)
 
 	attrs := spans[0].Attributes()
 	assert.Contains(t, attrs, internal.MemcacheDBSystem())
 	assert.Contains(t, attrs, internal.MemcacheDBOperation(internal.OperationGet))
 	assert.Contains(t, attrs, internal.MemcacheDBItemKeyName.String(key))
+	assert.Contains(t, attrs, internal.MemcacheCacheHit(false))
 
-	assert.Equal(t, codes.Error, spans[0].Status().Code)
-	assert.Equal(t, err.Error(), spans[0].Status().Description)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Empty(t, spans[0].Status().Description)
+}
+
+func TestGetMulti(t *testing.T) {
+	c, sr := initClientWithSpanRecorder(t)
+
+	require.NoError(t, c.Set(&memcache.Item{Key: "foo", Value: []byte("bar")}))
+	items, err := c.GetMulti([]string{"foo", "baz"})
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	attrs := spans[1].Attributes()
+	assert.Contains(t, attrs, internal.MemcacheHitCount(1))
+	assert.Contains(t, attrs, internal.MemcacheMissCount(1))
+	assert.Contains(t, attrs, internal.MemcacheDBItemSize(3))
 }
 
 // tests require running memcached instance.
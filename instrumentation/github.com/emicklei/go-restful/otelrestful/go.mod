@@ -6,9 +6,12 @@ replace go.opentelemetry.io/contrib/propagators/b3 => ../../../../../propagators
 
 require (
 	github.com/emicklei/go-restful/v3 v3.10.1
+	github.com/felixge/httpsnoop v1.0.3
+	github.com/helios/go-sdk/data-utils v1.0.2
 	github.com/stretchr/testify v1.8.1
 	go.opentelemetry.io/contrib/propagators/b3 v1.12.0
 	go.opentelemetry.io/otel v1.11.2
+	go.opentelemetry.io/otel/metric v0.34.0
 	go.opentelemetry.io/otel/trace v1.11.2
 )
 
@@ -19,6 +22,8 @@ require (
 	github.com/json-iterator/go v1.1.10 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
 	github.com/modern-go/reflect2 v0.0.0-20180701023420-4b7aa43c6742 // indirect
+	github.com/ohler55/ojg v1.17.4 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/exp v0.0.0-20230203172020-98cc5a0785f9 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver // import "go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful/internal/httpserver"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PublicEndpoint returns the options starting the span of r as a new root
+// when r reached a public endpoint: always if public is set, otherwise when
+// fn returns true for r. The new root is linked to the remote span context
+// extracted into ctx, if any.
+func PublicEndpoint(ctx context.Context, r *http.Request, public bool, fn func(*http.Request) bool) []trace.SpanStartOption {
+	if !public && (fn == nil || !fn(r.WithContext(ctx))) {
+		return nil
+	}
+	opts := []trace.SpanStartOption{trace.WithNewRoot()}
+	if s := trace.SpanContextFromContext(ctx); s.IsValid() && s.IsRemote() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: s}))
+	}
+	return opts
+}
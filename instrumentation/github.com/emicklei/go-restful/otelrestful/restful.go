@@ -15,9 +15,20 @@
 package otelrestful // import "go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful"
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
 	"github.com/emicklei/go-restful/v3"
+	"github.com/felixge/httpsnoop"
+	datautils "github.com/helios/go-sdk/data-utils"
 
+	"go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful/internal/httpserver"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -25,6 +36,68 @@ import (
 
 const tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful"
 
+var _ io.ReadCloser = &bodyWrapper{}
+
+// bodyWrapper wraps a http.Request.Body (an io.ReadCloser) to track the number
+// of bytes read and collect the content read by the handlers.
+type bodyWrapper struct {
+	io.ReadCloser
+	read         int64
+	requestBody  []byte
+	metadataOnly bool
+	contentType  string
+}
+
+func (w *bodyWrapper) Read(b []byte) (int, error) {
+	n, err := w.ReadCloser.Read(b)
+	if n > 0 && !w.metadataOnly {
+		shouldSkipContentByType, _ := datautils.ShouldSkipContentCollectionByContentType(w.contentType)
+		if !shouldSkipContentByType {
+			w.requestBody = append(w.requestBody, b[0:n]...)
+		}
+	}
+	w.read += int64(n)
+	return n, err
+}
+
+func (w *bodyWrapper) Close() error {
+	return w.ReadCloser.Close()
+}
+
+// recordResponse wraps the http.ResponseWriter of resp to collect the content
+// written by the handlers. The returned function restores the original writer
+// and returns the collected content.
+func recordResponse(resp *restful.Response, metadataOnly bool) func() []byte {
+	writer := resp.ResponseWriter
+	var responseBody []byte
+	resp.ResponseWriter = httpsnoop.Wrap(writer, httpsnoop.Hooks{
+		Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+			return func(b []byte) (int, error) {
+				if !metadataOnly && len(b) > 0 {
+					respContentType := writer.Header().Get("Content-Type")
+					shouldSkipContentByType, _ := datautils.ShouldSkipContentCollectionByContentType(respContentType)
+					if !shouldSkipContentByType {
+						responseBody = append(responseBody, b...)
+					}
+				}
+				return next(b)
+			}
+		},
+	})
+	return func() []byte {
+		resp.ResponseWriter = writer
+		return responseBody
+	}
+}
+
+func collectRequestHeaders(r *http.Request, span oteltrace.Span) {
+	headersStr, err := json.Marshal(r.Header)
+	if err == nil {
+		attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.request.headers", Value: attribute.StringValue(string(headersStr))})
+		span.SetAttributes(attr)
+	}
+}
+
 // OTelFilter returns a restful.FilterFunction which will trace an incoming request.
 //
 // The service parameter should describe the name of the (virtual) server handling
@@ -45,28 +118,75 @@ func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 	if cfg.Propagators == nil {
 		cfg.Propagators = otel.GetTextMapPropagator()
 	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = global.MeterProvider()
+	}
+	instruments := httpserver.NewInstruments(
+		cfg.MeterProvider.Meter(tracerName, metric.WithInstrumentationVersion(SemVersion())),
+	)
 	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 		r := req.Request
+		for _, f := range cfg.Filters {
+			if !f(r) {
+				// Serve the request to the next filter
+				// if a filter rejects the request.
+				chain.ProcessFilter(req, resp)
+				return
+			}
+		}
+
 		ctx := cfg.Propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 		route := req.SelectedRoutePath()
 		spanName := route
+		if cfg.SpanNameFormatter != nil {
+			spanName = cfg.SpanNameFormatter(route, r)
+		}
+
+		metadataOnly := os.Getenv("HS_METADATA_ONLY") == "true"
+		var bw bodyWrapper
+		if r.Body != nil && r.Body != http.NoBody {
+			bw.ReadCloser = r.Body
+			bw.metadataOnly = metadataOnly
+			bw.contentType = r.Header.Get("Content-Type")
+			r.Body = &bw
+		}
+		restore := recordResponse(resp, metadataOnly)
 
-		ctx, span := tracer.Start(ctx, spanName,
+		finished := instruments.Started(ctx, httpserver.RequestAttrs(service, route, r))
+		defer func() { finished(resp.StatusCode(), bw.read, int64(resp.ContentLength())) }()
+		opts := []oteltrace.SpanStartOption{
 			oteltrace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", r)...),
 			oteltrace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(r)...),
 			oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(service, route, r)...),
 			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
-		)
+		}
+		opts = append(opts, cfg.SpanStartOptions...)
+		opts = append(opts, httpserver.PublicEndpoint(ctx, r, cfg.PublicEndpoint, cfg.PublicEndpointFn)...)
+		ctx, span := tracer.Start(ctx, spanName, opts...)
 		defer span.End()
 
 		// pass the span through the request context
 		req.Request = req.Request.WithContext(ctx)
 
 		chain.ProcessFilter(req, resp)
+		responseBody := restore()
 
 		attrs := semconv.HTTPAttributesFromHTTPStatusCode(resp.StatusCode())
 		spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(resp.StatusCode(), oteltrace.SpanKindServer)
 		span.SetAttributes(attrs...)
 		span.SetStatus(spanStatus, spanMessage)
+
+		if !metadataOnly {
+			collectRequestHeaders(r, span)
+			if len(bw.requestBody) > 0 {
+				attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.request.body", Value: attribute.StringValue(string(bw.requestBody))})
+				span.SetAttributes(attr)
+			}
+
+			if len(responseBody) > 0 {
+				attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.response.body", Value: attribute.StringValue(string(responseBody))})
+				span.SetAttributes(attr)
+			}
+		}
 	}
 }
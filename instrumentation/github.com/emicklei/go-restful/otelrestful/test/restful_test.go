@@ -15,9 +15,12 @@
 package test
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/emicklei/go-restful/v3"
@@ -28,8 +31,12 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -213,3 +220,153 @@ func assertSpan(t *testing.T, span sdktrace.ReadOnlySpan, name string, attrs ...
 		assert.Contains(t, gotA, a)
 	}
 }
+
+func TestMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	handlerFunc := func(req *restful.Request, resp *restful.Response) {
+		_, _ = resp.Write([]byte("ok"))
+	}
+	ws := &restful.WebService{}
+	ws.Route(ws.GET("/user/{id}").To(handlerFunc))
+
+	container := restful.NewContainer()
+	container.Filter(otelrestful.OTelFilter("my-service", otelrestful.WithMeterProvider(meterProvider)))
+	container.Add(ws)
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	w := httptest.NewRecorder()
+	container.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	assertServerMetrics(t, reader, "/user/{id}", http.StatusOK, 2)
+}
+
+func TestMiddlewareOptions(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	remote := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: oteltrace.TraceID{0x01},
+		SpanID:  oteltrace.SpanID{0x01},
+		Remote:  true,
+	})
+
+	handlerFunc := func(req *restful.Request, resp *restful.Response) {}
+	ws := &restful.WebService{}
+	ws.Route(ws.GET("/user/{id}").To(handlerFunc))
+	ws.Route(ws.GET("/healthcheck").To(handlerFunc))
+
+	container := restful.NewContainer()
+	container.Filter(otelrestful.OTelFilter("my-service",
+		otelrestful.WithTracerProvider(provider),
+		otelrestful.WithPropagators(propagation.TraceContext{}),
+		otelrestful.WithPublicEndpoint(),
+		otelrestful.WithSpanNameFormatter(func(route string, r *http.Request) string { return r.Method + " " + route }),
+		otelrestful.WithSpanOptions(oteltrace.WithAttributes(attribute.String("edge", "true"))),
+		otelrestful.WithFilter(func(r *http.Request) bool { return r.URL.Path != "/healthcheck" }),
+	))
+	container.Add(ws)
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	ctx := oteltrace.ContextWithRemoteSpanContext(context.Background(), remote)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	container.ServeHTTP(httptest.NewRecorder(), r)
+	container.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthcheck", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /user/{id}", span.Name())
+	assert.Contains(t, span.Attributes(), attribute.String("edge", "true"))
+	assert.NotEqual(t, remote.TraceID(), span.SpanContext().TraceID())
+	require.Len(t, span.Links(), 1)
+	assert.Equal(t, remote, span.Links()[0].SpanContext)
+}
+
+func TestPayloadCapture(t *testing.T) {
+	serve := func(sr *tracetest.SpanRecorder) *httptest.ResponseRecorder {
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+		handlerFunc := func(req *restful.Request, resp *restful.Response) {
+			body, err := io.ReadAll(req.Request.Body)
+			require.NoError(t, err)
+			resp.Header().Set("Content-Type", "application/json")
+			_, _ = resp.Write(body)
+		}
+		ws := &restful.WebService{}
+		ws.Route(ws.POST("/user").To(handlerFunc))
+
+		container := restful.NewContainer()
+		container.Filter(otelrestful.OTelFilter("my-service", otelrestful.WithTracerProvider(provider)))
+		container.Add(ws)
+
+		r := httptest.NewRequest("POST", "/user", strings.NewReader(`{"name":"gopher"}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		container.ServeHTTP(w, r)
+		return w
+	}
+	keys := func(sr *tracetest.SpanRecorder) map[attribute.Key]struct{} {
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		m := make(map[attribute.Key]struct{})
+		for _, kv := range spans[0].Attributes() {
+			m[kv.Key] = struct{}{}
+		}
+		return m
+	}
+
+	t.Run("captures body and headers", func(t *testing.T) {
+		sr := tracetest.NewSpanRecorder()
+		w := serve(sr)
+		assert.JSONEq(t, `{"name":"gopher"}`, w.Body.String())
+
+		attrs := keys(sr)
+		assert.Contains(t, attrs, attribute.Key("http.request.body"))
+		assert.Contains(t, attrs, attribute.Key("http.response.body"))
+		assert.Contains(t, attrs, attribute.Key("http.request.headers"))
+	})
+
+	t.Run("metadata only", func(t *testing.T) {
+		t.Setenv("HS_METADATA_ONLY", "true")
+
+		sr := tracetest.NewSpanRecorder()
+		w := serve(sr)
+		assert.JSONEq(t, `{"name":"gopher"}`, w.Body.String())
+
+		attrs := keys(sr)
+		assert.NotContains(t, attrs, attribute.Key("http.request.body"))
+		assert.NotContains(t, attrs, attribute.Key("http.response.body"))
+		assert.NotContains(t, attrs, attribute.Key("http.request.headers"))
+	})
+}
+
+// assertServerMetrics asserts that reader collected the metrics of a single
+// served request to route, answered with status and written response bytes.
+// An empty route asserts that requests are not identified by their route.
+func assertServerMetrics(t *testing.T, reader metric.Reader, route string, status int, written int64) {
+	t.Helper()
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	sums := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			require.Len(t, data.DataPoints, 1)
+			sums[m.Name] = data.DataPoints[0].Value
+		case metricdata.Histogram:
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, uint64(1), data.DataPoints[0].Count)
+			r, ok := data.DataPoints[0].Attributes.Value(semconv.HTTPRouteKey)
+			assert.Equal(t, route != "", ok)
+			assert.Equal(t, route, r.AsString())
+			s, _ := data.DataPoints[0].Attributes.Value(semconv.HTTPStatusCodeKey)
+			assert.Equal(t, int64(status), s.AsInt64())
+		}
+	}
+	assert.Equal(t, written, sums["http.server.response_content_length"])
+	assert.Equal(t, int64(0), sums["http.server.active_requests"])
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgin // import "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	datautils "github.com/helios/go-sdk/data-utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys recorded for the errors attached to a request.
+const (
+	ErrorTypeKey = attribute.Key("gin.error.type")  // type of the error of an exception event: private, public, bind or render
+	ErrorMetaKey = attribute.Key("gin.error.meta")  // JSON encoded metadata of the error of an exception event
+	BindErrorKey = attribute.Key("gin.errors.bind") // true if the request failed to bind
+)
+
+// errorStatus is the span status set for errors of a type.
+type errorStatus struct {
+	errorType gin.ErrorType
+	code      codes.Code
+}
+
+// errorTypeName returns the name of the type of a gin error.
+func errorTypeName(t gin.ErrorType) string {
+	switch {
+	case t&gin.ErrorTypeBind != 0:
+		return "bind"
+	case t&gin.ErrorTypeRender != 0:
+		return "render"
+	case t&gin.ErrorTypePublic != 0:
+		return "public"
+	case t&gin.ErrorTypePrivate != 0:
+		return "private"
+	default:
+		return "unknown"
+	}
+}
+
+// recordErrors records every error as an exception event on span and returns
+// the span status, given the status derived from the HTTP status code, once
+// the statuses configured for the error types are applied.
+func recordErrors(span oteltrace.Span, errs []*gin.Error, statuses []errorStatus, metadataOnly bool, code codes.Code, description string) (codes.Code, string) {
+	bind := false
+	for _, e := range errs {
+		if e == nil || e.Err == nil {
+			continue
+		}
+
+		attrs := []attribute.KeyValue{ErrorTypeKey.String(errorTypeName(e.Type))}
+		if e.Meta != nil && !metadataOnly {
+			if b, err := json.Marshal(e.Meta); err == nil {
+				attrs = append(attrs, datautils.ObfuscateAttributeValue(ErrorMetaKey.String(string(b))))
+			}
+		}
+		span.RecordError(e.Err, oteltrace.WithAttributes(attrs...))
+
+		if e.IsType(gin.ErrorTypeBind) {
+			bind = true
+		}
+		for _, s := range statuses {
+			if !e.IsType(s.errorType) {
+				continue
+			}
+			code, description = s.code, ""
+			if s.code == codes.Error {
+				description = e.Error()
+			}
+		}
+	}
+	if bind {
+		span.SetAttributes(BindErrorKey.Bool(true))
+	}
+	return code, description
+}
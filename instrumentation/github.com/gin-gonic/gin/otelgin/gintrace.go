@@ -17,12 +17,21 @@
 package otelgin // import "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
 
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin/internal/httpserver"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+
+	datautils "github.com/helios/go-sdk/data-utils"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
@@ -35,6 +44,82 @@ const (
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+var _ io.ReadCloser = &bodyWrapper{}
+
+// bodyWrapper wraps a http.Request.Body (an io.ReadCloser) to track the number
+// of bytes read and collect the content read by the handlers.
+type bodyWrapper struct {
+	io.ReadCloser
+	read         int64
+	requestBody  []byte
+	metadataOnly bool
+	contentType  string
+}
+
+func (w *bodyWrapper) Read(b []byte) (int, error) {
+	n, err := w.ReadCloser.Read(b)
+	if n > 0 && !w.metadataOnly {
+		shouldSkipContentByType, _ := datautils.ShouldSkipContentCollectionByContentType(w.contentType)
+		if !shouldSkipContentByType {
+			w.requestBody = append(w.requestBody, b[0:n]...)
+		}
+	}
+	w.read += int64(n)
+	return n, err
+}
+
+func (w *bodyWrapper) Close() error {
+	return w.ReadCloser.Close()
+}
+
+var _ gin.ResponseWriter = &recordingResponseWriter{}
+
+// recordingResponseWriter wraps a gin.ResponseWriter to collect the content
+// written by the handlers.
+type recordingResponseWriter struct {
+	gin.ResponseWriter
+	responseBody []byte
+	metadataOnly bool
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.record(b[:n])
+	return n, err
+}
+
+func (w *recordingResponseWriter) WriteString(s string) (int, error) {
+	n, err := w.ResponseWriter.WriteString(s)
+	w.record([]byte(s[:n]))
+	return n, err
+}
+
+func (w *recordingResponseWriter) record(b []byte) {
+	if w.metadataOnly || len(b) == 0 {
+		return
+	}
+	shouldSkipContentByType, _ := datautils.ShouldSkipContentCollectionByContentType(w.Header().Get("Content-Type"))
+	if !shouldSkipContentByType {
+		w.responseBody = append(w.responseBody, b...)
+	}
+}
+
+// written returns the number of bytes written to the response body.
+func written(w gin.ResponseWriter) int64 {
+	if n := w.Size(); n > 0 {
+		return int64(n)
+	}
+	return 0
+}
+
+func collectRequestHeaders(r *http.Request, span oteltrace.Span) {
+	headersStr, err := json.Marshal(r.Header)
+	if err == nil {
+		attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.request.headers", Value: attribute.StringValue(string(headersStr))})
+		span.SetAttributes(attr)
+	}
+}
+
 // Middleware returns middleware that will trace incoming requests.
 // The service parameter should describe the name of the (virtual)
 // server handling the request.
@@ -53,6 +138,12 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 	if cfg.Propagators == nil {
 		cfg.Propagators = otel.GetTextMapPropagator()
 	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = global.MeterProvider()
+	}
+	instruments := httpserver.NewInstruments(
+		cfg.MeterProvider.Meter(tracerName, metric.WithInstrumentationVersion(SemVersion())),
+	)
 	return func(c *gin.Context) {
 		for _, f := range cfg.Filters {
 			if !f(c.Request) {
@@ -64,8 +155,10 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 		}
 		c.Set(tracerKey, tracer)
 		savedCtx := c.Request.Context()
+		savedWriter := c.Writer
 		defer func() {
 			c.Request = c.Request.WithContext(savedCtx)
+			c.Writer = savedWriter
 		}()
 		ctx := cfg.Propagators.Extract(savedCtx, propagation.HeaderCarrier(c.Request.Header))
 		opts := []oteltrace.SpanStartOption{
@@ -74,10 +167,28 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 			oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(service, c.FullPath(), c.Request)...),
 			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 		}
+		opts = append(opts, cfg.SpanStartOptions...)
+		opts = append(opts, httpserver.PublicEndpoint(ctx, c.Request, cfg.PublicEndpoint, cfg.PublicEndpointFn)...)
 		spanName := c.FullPath()
 		if spanName == "" {
 			spanName = fmt.Sprintf("HTTP %s route not found", c.Request.Method)
 		}
+		if cfg.SpanNameFormatter != nil {
+			spanName = cfg.SpanNameFormatter(spanName, c.Request)
+		}
+		metadataOnly := os.Getenv("HS_METADATA_ONLY") == "true"
+		var bw bodyWrapper
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			bw.ReadCloser = c.Request.Body
+			bw.metadataOnly = metadataOnly
+			bw.contentType = c.Request.Header.Get("Content-Type")
+			c.Request.Body = &bw
+		}
+		rrw := &recordingResponseWriter{ResponseWriter: c.Writer, metadataOnly: metadataOnly}
+		c.Writer = rrw
+
+		finished := instruments.Started(ctx, httpserver.RequestAttrs(service, c.FullPath(), c.Request))
+		defer func() { finished(c.Writer.Status(), bw.read, written(c.Writer)) }()
 		ctx, span := tracer.Start(ctx, spanName, opts...)
 		defer span.End()
 
@@ -91,9 +202,23 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 		attrs := semconv.HTTPAttributesFromHTTPStatusCode(status)
 		spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, oteltrace.SpanKindServer)
 		span.SetAttributes(attrs...)
-		span.SetStatus(spanStatus, spanMessage)
 		if len(c.Errors) > 0 {
 			span.SetAttributes(attribute.String("gin.errors", c.Errors.String()))
+			spanStatus, spanMessage = recordErrors(span, c.Errors, cfg.ErrorStatuses, metadataOnly, spanStatus, spanMessage)
+		}
+		span.SetStatus(spanStatus, spanMessage)
+
+		if !metadataOnly {
+			collectRequestHeaders(c.Request, span)
+			if len(bw.requestBody) > 0 {
+				attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.request.body", Value: attribute.StringValue(string(bw.requestBody))})
+				span.SetAttributes(attr)
+			}
+
+			if len(rrw.responseBody) > 0 {
+				attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.response.body", Value: attribute.StringValue(string(rrw.responseBody))})
+				span.SetAttributes(attr)
+			}
 		}
 	}
 }
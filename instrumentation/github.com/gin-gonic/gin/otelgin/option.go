@@ -19,14 +19,24 @@ package otelgin // import "go.opentelemetry.io/contrib/instrumentation/github.co
 import (
 	"net/http"
 
+	"github.com/gin-gonic/gin"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
-	TracerProvider oteltrace.TracerProvider
-	Propagators    propagation.TextMapPropagator
-	Filters        []Filter
+	TracerProvider    oteltrace.TracerProvider
+	MeterProvider     metric.MeterProvider
+	Propagators       propagation.TextMapPropagator
+	Filters           []Filter
+	ErrorStatuses     []errorStatus
+	SpanNameFormatter func(string, *http.Request) string
+	PublicEndpoint    bool
+	PublicEndpointFn  func(*http.Request) bool
+	SpanStartOptions  []oteltrace.SpanStartOption
 }
 
 // Filter is a predicate used to determine whether a given http.request should
@@ -65,6 +75,16 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithFilter adds a filter to the list of filters used by the handler.
 // If any filter indicates to exclude a request then the request will not be
 // traced. All filters must allow a request to be traced for a Span to be created.
@@ -76,3 +96,49 @@ func WithFilter(f ...Filter) Option {
 		c.Filters = append(c.Filters, f...)
 	})
 }
+
+// WithErrorTypeStatus sets the status of the span to code when an error of
+// type t was attached to the request with gin.Context.Error. It overrides the
+// status derived from the HTTP status code, so bind errors can, for instance,
+// be reported as errors although they are answered with a 4xx status code.
+// When several errors match, the last error attached to the request decides.
+func WithErrorTypeStatus(t gin.ErrorType, code codes.Code) Option {
+	return optionFunc(func(c *config) {
+		c.ErrorStatuses = append(c.ErrorStatuses, errorStatus{errorType: t, code: code})
+	})
+}
+
+// WithSpanNameFormatter takes a function that will be called on every
+// request and the returned string will become the span name. The function is
+// given the default span name, the route template of the request.
+func WithSpanNameFormatter(f func(route string, r *http.Request) string) Option {
+	return optionFunc(func(c *config) {
+		c.SpanNameFormatter = f
+	})
+}
+
+// WithPublicEndpoint starts the span of every request handled by the gin
+// middleware as a new root, linked to the incoming span context instead of
+// being its child. Use it for services reached from outside the trace.
+func WithPublicEndpoint() Option {
+	return optionFunc(func(c *config) {
+		c.PublicEndpoint = true
+	})
+}
+
+// WithPublicEndpointFn does what WithPublicEndpoint does for the requests fn
+// returns true for, fn being called with every request. It is ignored once
+// WithPublicEndpoint is set.
+func WithPublicEndpointFn(fn func(*http.Request) bool) Option {
+	return optionFunc(func(c *config) {
+		c.PublicEndpointFn = fn
+	})
+}
+
+// WithSpanOptions configures an additional set of
+// trace.SpanOptions, which are applied to each new span.
+func WithSpanOptions(opts ...oteltrace.SpanStartOption) Option {
+	return optionFunc(func(c *config) {
+		c.SpanStartOptions = append(c.SpanStartOptions, opts...)
+	})
+}
@@ -17,11 +17,13 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"html/template"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -31,8 +33,12 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 
 	"go.opentelemetry.io/otel/attribute"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -237,3 +243,249 @@ func TestWithFilter(t *testing.T) {
 		assert.Len(t, sr.Ended(), 1)
 	})
 }
+
+func TestPayloadCapture(t *testing.T) {
+	newRouter := func(sr *tracetest.SpanRecorder) *gin.Engine {
+		router := gin.New()
+		router.Use(otelgin.Middleware("foobar", otelgin.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))))
+		router.POST("/user", func(c *gin.Context) {
+			var user struct {
+				Name string `json:"name"`
+			}
+			require.NoError(t, c.BindJSON(&user))
+			c.JSON(http.StatusCreated, user)
+		})
+		return router
+	}
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("POST", "/user", strings.NewReader(`{"name":"gopher"}`))
+		r.Header.Set("Content-Type", "application/json")
+		return r
+	}
+	keys := func(span sdktrace.ReadOnlySpan) map[attribute.Key]struct{} {
+		m := make(map[attribute.Key]struct{})
+		for _, kv := range span.Attributes() {
+			m[kv.Key] = struct{}{}
+		}
+		return m
+	}
+
+	t.Run("captures body and headers", func(t *testing.T) {
+		sr := tracetest.NewSpanRecorder()
+		w := httptest.NewRecorder()
+		newRouter(sr).ServeHTTP(w, newRequest())
+		require.Equal(t, http.StatusCreated, w.Result().StatusCode)
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		attrs := keys(spans[0])
+		assert.Contains(t, attrs, attribute.Key("http.request.body"))
+		assert.Contains(t, attrs, attribute.Key("http.response.body"))
+		assert.Contains(t, attrs, attribute.Key("http.request.headers"))
+	})
+
+	t.Run("metadata only", func(t *testing.T) {
+		t.Setenv("HS_METADATA_ONLY", "true")
+
+		sr := tracetest.NewSpanRecorder()
+		w := httptest.NewRecorder()
+		newRouter(sr).ServeHTTP(w, newRequest())
+		require.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.JSONEq(t, `{"name":"gopher"}`, w.Body.String())
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		attrs := keys(spans[0])
+		assert.NotContains(t, attrs, attribute.Key("http.request.body"))
+		assert.NotContains(t, attrs, attribute.Key("http.response.body"))
+		assert.NotContains(t, attrs, attribute.Key("http.request.headers"))
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	router := gin.New()
+	router.Use(otelgin.Middleware("foobar", otelgin.WithMeterProvider(meterProvider)))
+	router.GET("/user/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	assertServerMetrics(t, reader, "/user/:id", http.StatusOK, 2)
+}
+
+func TestMetricsPanic(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	router := gin.New()
+	router.Use(gin.Recovery(), otelgin.Middleware("foobar", otelgin.WithMeterProvider(meterProvider)))
+	router.GET("/user/:id", func(c *gin.Context) {
+		panic("handler failed")
+	})
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name == "http.server.active_requests" {
+			data, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, int64(0), data.DataPoints[0].Value, "a panicking handler must not leave the request active")
+		}
+	}
+}
+
+func TestErrorEvents(t *testing.T) {
+	newRouter := func(sr *tracetest.SpanRecorder, opts ...otelgin.Option) *gin.Engine {
+		opts = append(opts, otelgin.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))))
+		router := gin.New()
+		router.Use(otelgin.Middleware("foobar", opts...))
+		router.POST("/user", func(c *gin.Context) {
+			var user struct {
+				Name string `json:"name" binding:"required"`
+			}
+			if c.BindJSON(&user) != nil {
+				return
+			}
+			c.Status(http.StatusCreated)
+		})
+		router.GET("/user/:id", func(c *gin.Context) {
+			_ = c.Error(errors.New("lookup failed")).SetMeta(map[string]string{"id": c.Param("id")})
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+
+	t.Run("bind error", func(t *testing.T) {
+		sr := tracetest.NewSpanRecorder()
+		r := httptest.NewRequest("POST", "/user", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+		newRouter(sr).ServeHTTP(w, r)
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Contains(t, span.Attributes(), otelgin.BindErrorKey.Bool(true))
+		assert.Equal(t, codes.Unset, span.Status().Code)
+		require.Len(t, span.Events(), 1)
+		assert.Equal(t, "exception", span.Events()[0].Name)
+		assert.Contains(t, span.Events()[0].Attributes, otelgin.ErrorTypeKey.String("bind"))
+	})
+
+	t.Run("bind error status", func(t *testing.T) {
+		sr := tracetest.NewSpanRecorder()
+		r := httptest.NewRequest("POST", "/user", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+		newRouter(sr, otelgin.WithErrorTypeStatus(gin.ErrorTypeBind, codes.Error)).ServeHTTP(w, r)
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+
+	t.Run("private error", func(t *testing.T) {
+		sr := tracetest.NewSpanRecorder()
+		r := httptest.NewRequest("GET", "/user/123", nil)
+		w := httptest.NewRecorder()
+		newRouter(sr).ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.NotContains(t, span.Attributes(), otelgin.BindErrorKey.Bool(true))
+		assert.Equal(t, codes.Unset, span.Status().Code)
+		require.Len(t, span.Events(), 1)
+		attrs := span.Events()[0].Attributes
+		assert.Contains(t, attrs, otelgin.ErrorTypeKey.String("private"))
+		assert.Contains(t, attrs, attribute.String("exception.message", "lookup failed"))
+
+		keys := make(map[attribute.Key]struct{})
+		for _, kv := range attrs {
+			keys[kv.Key] = struct{}{}
+		}
+		assert.Contains(t, keys, otelgin.ErrorMetaKey)
+	})
+}
+
+func TestMiddlewareOptions(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	remote := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: oteltrace.TraceID{0x01},
+		SpanID:  oteltrace.SpanID{0x01},
+		Remote:  true,
+	})
+
+	router := gin.New()
+	router.Use(otelgin.Middleware("foobar",
+		otelgin.WithTracerProvider(provider),
+		otelgin.WithPropagators(propagation.TraceContext{}),
+		otelgin.WithPublicEndpoint(),
+		otelgin.WithSpanNameFormatter(func(route string, r *http.Request) string { return r.Method + " " + route }),
+		otelgin.WithSpanOptions(oteltrace.WithAttributes(attribute.String("edge", "true"))),
+		otelgin.WithFilter(func(r *http.Request) bool { return r.URL.Path != "/healthcheck" }),
+	))
+	router.GET("/user/:id", func(c *gin.Context) {})
+	router.GET("/healthcheck", func(c *gin.Context) {})
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	ctx := oteltrace.ContextWithRemoteSpanContext(context.Background(), remote)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	router.ServeHTTP(httptest.NewRecorder(), r)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthcheck", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /user/:id", span.Name())
+	assert.Contains(t, span.Attributes(), attribute.String("edge", "true"))
+	assert.NotEqual(t, remote.TraceID(), span.SpanContext().TraceID())
+	require.Len(t, span.Links(), 1)
+	assert.Equal(t, remote, span.Links()[0].SpanContext)
+}
+
+// assertServerMetrics asserts that reader collected the metrics of a single
+// served request to route, answered with status and written response bytes.
+// An empty route asserts that requests are not identified by their route.
+func assertServerMetrics(t *testing.T, reader metric.Reader, route string, status int, written int64) {
+	t.Helper()
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	sums := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			require.Len(t, data.DataPoints, 1)
+			sums[m.Name] = data.DataPoints[0].Value
+		case metricdata.Histogram:
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, uint64(1), data.DataPoints[0].Count)
+			r, ok := data.DataPoints[0].Attributes.Value(semconv.HTTPRouteKey)
+			assert.Equal(t, route != "", ok)
+			assert.Equal(t, route, r.AsString())
+			s, _ := data.DataPoints[0].Attributes.Value(semconv.HTTPStatusCodeKey)
+			assert.Equal(t, int64(status), s.AsInt64())
+		}
+	}
+	assert.Equal(t, written, sums["http.server.response_content_length"])
+	assert.Equal(t, int64(0), sums["http.server.active_requests"])
+}
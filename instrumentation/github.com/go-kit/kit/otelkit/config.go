@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -28,10 +29,18 @@ type config struct {
 	// TracerProvider provides access to instrumentation Tracers.
 	TracerProvider trace.TracerProvider
 
+	// MeterProvider provides access to instrumentation Meters.
+	MeterProvider metric.MeterProvider
+
 	// IgnoreBusinessError if set to true will not treat a business error
 	// identified through the endpoint.Failer interface as a span error.
 	IgnoreBusinessError bool
 
+	// ErrorClassifier determines the outcome of endpoint calls, which labels
+	// the endpoint metrics. Only calls classified as OutcomeError, or as
+	// OutcomeBusinessError unless IgnoreBusinessError is set, are span errors.
+	ErrorClassifier ErrorClassifier
+
 	// Operation identifies the current operation and serves as a span name.
 	Operation string
 
@@ -49,6 +58,17 @@ type config struct {
 	GetAttributes func(ctx context.Context) []attribute.KeyValue
 }
 
+// failed reports whether calls with the outcome are span errors.
+func (cfg *config) failed(outcome Outcome) bool {
+	switch outcome {
+	case OutcomeError:
+		return true
+	case OutcomeBusinessError:
+		return !cfg.IgnoreBusinessError
+	}
+	return false
+}
+
 // Option configures an EndpointMiddleware.
 type Option interface {
 	apply(*config)
@@ -70,6 +90,26 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(o *config) {
+		if provider != nil {
+			o.MeterProvider = provider
+		}
+	})
+}
+
+// WithErrorClassifier sets the function classifying the outcome of endpoint
+// calls. It can be used to treat expected business failures as successes,
+// or specific errors as business errors. If none is specified,
+// DefaultErrorClassifier is used.
+func WithErrorClassifier(fn ErrorClassifier) Option {
+	return optionFunc(func(o *config) {
+		o.ErrorClassifier = fn
+	})
+}
+
 // WithIgnoreBusinessError if set to true will not treat a business error
 // identified through the endpoint.Failer interface as a span error.
 func WithIgnoreBusinessError(val bool) Option {
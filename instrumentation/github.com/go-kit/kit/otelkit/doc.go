@@ -14,9 +14,10 @@
 
 // Package otelkit instruments the github.com/go-kit/kit package.
 //
-// Compared to other instrumentation libraries provided by go-kit itself,
-// this package only provides instrumentation for the endpoint layer.
-// For instrumenting the transport layer,
-// look at the instrumentation libraries provided by go.opentelemetry.io/contrib.
+// This package provides instrumentation for the endpoint layer. The transport
+// layer is instrumented by the http and grpc subpackages, whose options trace
+// go-kit HTTP and gRPC servers and clients and propagate the trace context
+// between them. Endpoint spans can be named after the transport operation with
+// WithOperationGetter(OperationFromContext).
 // Learn more about go-kit's layers at https://gokit.io/faq/#architecture-and-design.
 package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
@@ -18,10 +18,12 @@ package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.co
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/go-kit/kit/endpoint"
@@ -50,10 +52,19 @@ func EndpointMiddleware(options ...Option) endpoint.Middleware {
 		cfg.TracerProvider = otel.GetTracerProvider()
 	}
 
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = global.MeterProvider()
+	}
+
+	if cfg.ErrorClassifier == nil {
+		cfg.ErrorClassifier = DefaultErrorClassifier
+	}
+
 	tracer := cfg.TracerProvider.Tracer(
 		tracerName,
 		trace.WithInstrumentationVersion(SemVersion()),
 	)
+	instruments := newInstruments(cfg.MeterProvider)
 
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
@@ -78,10 +89,14 @@ func EndpointMiddleware(options ...Option) endpoint.Middleware {
 				opts = append(opts, trace.WithAttributes(cfg.GetAttributes(ctx)...))
 			}
 
+			start := time.Now()
 			ctx, span := tracer.Start(ctx, spanName, opts...)
 			defer span.End()
 
 			defer func() {
+				outcome := cfg.ErrorClassifier(ctx, response, err)
+				instruments.record(ctx, spanName, outcome, start)
+
 				if err != nil {
 					if lberr, ok := err.(lb.RetryError); ok {
 						// Handle errors originating from lb.Retry.
@@ -92,14 +107,18 @@ func EndpointMiddleware(options ...Option) endpoint.Middleware {
 						}
 
 						span.RecordError(lberr.Final)
-						span.SetStatus(codes.Error, lberr.Error())
+						if cfg.failed(outcome) {
+							span.SetStatus(codes.Error, lberr.Error())
+						}
 
 						return
 					}
 
 					// generic error
 					span.RecordError(err)
-					span.SetStatus(codes.Error, err.Error())
+					if cfg.failed(outcome) {
+						span.SetStatus(codes.Error, err.Error())
+					}
 
 					return
 				}
@@ -111,7 +130,7 @@ func EndpointMiddleware(options ...Option) endpoint.Middleware {
 				if res, ok := response.(endpoint.Failer); ok && res.Failed() != nil {
 					span.RecordError(res.Failed())
 
-					if !cfg.IgnoreBusinessError {
+					if cfg.failed(outcome) {
 						span.SetStatus(codes.Error, res.Failed().Error())
 					}
 
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit/grpc"
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+)
+
+const tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit/grpc"
+
+// config holds the options for tracing a transport.
+type config struct {
+	// TracerProvider provides access to instrumentation Tracers.
+	TracerProvider trace.TracerProvider
+
+	// Propagators extract and inject the trace context of requests.
+	Propagators propagation.TextMapPropagator
+
+	// Operation identifies the requests of the transport and serves as a
+	// span name. It defaults to the full gRPC method of the request, without
+	// its leading slash.
+	Operation string
+
+	// GetOperation is an optional function that can set the span name based
+	// on the existing operation and information in the context.
+	//
+	// If the function is nil, or the returned operation is empty, the
+	// existing operation is used.
+	GetOperation func(ctx context.Context, operation string) string
+}
+
+// Option configures a transport tracing option.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) {
+	o(c)
+}
+
+func newConfig(options []Option) *config {
+	cfg := &config{}
+	for _, o := range options {
+		o.apply(cfg)
+	}
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.Propagators == nil {
+		cfg.Propagators = otel.GetTextMapPropagator()
+	}
+	return cfg
+}
+
+func (cfg *config) tracer() trace.Tracer {
+	return cfg.TracerProvider.Tracer(
+		tracerName,
+		trace.WithInstrumentationVersion(otelkit.SemVersion()),
+	)
+}
+
+// operation returns the operation of a request to the gRPC method.
+func (cfg *config) operation(ctx context.Context, method string) string {
+	operation := cfg.Operation
+	if operation == "" {
+		operation = strings.TrimPrefix(method, "/")
+	}
+	if cfg.GetOperation != nil {
+		if newOperation := cfg.GetOperation(ctx, operation); newOperation != "" {
+			operation = newOperation
+		}
+	}
+	return operation
+}
+
+// WithTracerProvider specifies a tracer provider to use for creating a tracer.
+// If none is specified, the global provider is used.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return optionFunc(func(o *config) {
+		if provider != nil {
+			o.TracerProvider = provider
+		}
+	})
+}
+
+// WithPropagators specifies propagators to use for extracting and injecting
+// the trace context of requests. If none are specified, global ones will be
+// used.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return optionFunc(func(o *config) {
+		if propagators != nil {
+			o.Propagators = propagators
+		}
+	})
+}
+
+// WithOperation sets the operation name of the requests of a transport.
+func WithOperation(operation string) Option {
+	return optionFunc(func(o *config) {
+		o.Operation = operation
+	})
+}
+
+// WithOperationGetter sets an operation name getter function in config.
+func WithOperationGetter(fn func(ctx context.Context, name string) string) Option {
+	return optionFunc(func(o *config) {
+		o.GetOperation = fn
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit/grpc"
+
+import (
+	"context"
+	"strings"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+)
+
+// ServerTrace returns a kitgrpc.ServerOption tracing the requests served by
+// a go-kit gRPC server. The span is started before the request is decoded and
+// ended once the response is encoded.
+func ServerTrace(options ...Option) kitgrpc.ServerOption {
+	cfg := newConfig(options)
+	tracer := cfg.tracer()
+
+	before := func(ctx context.Context, md metadata.MD) context.Context {
+		ctx = cfg.Propagators.Extract(ctx, metadataCarrier{metadata: &md})
+		method, _ := ctx.Value(kitgrpc.ContextKeyRequestMethod).(string)
+		if method == "" {
+			method, _ = grpc.Method(ctx)
+		}
+		operation := cfg.operation(ctx, method)
+		ctx, _ = tracer.Start(ctx, operation,
+			trace.WithAttributes(methodAttributes(method)...),
+			trace.WithSpanKind(trace.SpanKindServer),
+		)
+		return otelkit.ContextWithOperation(ctx, operation)
+	}
+
+	finalizer := func(ctx context.Context, err error) {
+		span := trace.SpanFromContext(ctx)
+		setStatus(span, err)
+		span.End()
+	}
+
+	return func(s *kitgrpc.Server) {
+		kitgrpc.ServerBefore(before)(s)
+		kitgrpc.ServerFinalizer(finalizer)(s)
+	}
+}
+
+// clientSpanKey is the context key of the span started by ClientTrace.
+type clientSpanKey struct{}
+
+// ClientTrace returns a kitgrpc.ClientOption tracing the requests sent by a
+// go-kit gRPC client. The context of the client span is injected into the
+// request metadata.
+func ClientTrace(options ...Option) kitgrpc.ClientOption {
+	cfg := newConfig(options)
+	tracer := cfg.tracer()
+
+	before := func(ctx context.Context, md *metadata.MD) context.Context {
+		method, _ := ctx.Value(kitgrpc.ContextKeyRequestMethod).(string)
+		operation := cfg.operation(ctx, method)
+		ctx, span := tracer.Start(ctx, operation,
+			trace.WithAttributes(methodAttributes(method)...),
+			trace.WithSpanKind(trace.SpanKindClient),
+		)
+		cfg.Propagators.Inject(ctx, metadataCarrier{metadata: md})
+		ctx = context.WithValue(ctx, clientSpanKey{}, span)
+		return otelkit.ContextWithOperation(ctx, operation)
+	}
+
+	finalizer := func(ctx context.Context, err error) {
+		// The finalizer also runs when the request failed to be encoded,
+		// before the client span was started.
+		span, ok := ctx.Value(clientSpanKey{}).(trace.Span)
+		if !ok {
+			return
+		}
+		setStatus(span, err)
+		span.End()
+	}
+
+	return func(c *kitgrpc.Client) {
+		kitgrpc.ClientBefore(before)(c)
+		kitgrpc.ClientFinalizer(finalizer)(c)
+	}
+}
+
+// methodAttributes returns the RPC attributes of a full gRPC method name,
+// formatted as "/service/method".
+func methodAttributes(fullMethod string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	name := strings.TrimPrefix(fullMethod, "/")
+	service, method, found := strings.Cut(name, "/")
+	if !found {
+		return attrs
+	}
+	if service != "" {
+		attrs = append(attrs, semconv.RPCServiceKey.String(service))
+	}
+	if method != "" {
+		attrs = append(attrs, semconv.RPCMethodKey.String(method))
+	}
+	return attrs
+}
+
+// setStatus records the gRPC status code of err on the span. The span status
+// is set to error for any error other than a nil one.
+func setStatus(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(s.Code())))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, s.Message())
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit/grpc"
+
+import (
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// metadataCarrier adapts gRPC metadata to the TextMapCarrier interface.
+type metadataCarrier struct {
+	metadata *metadata.MD
+}
+
+// assert that metadataCarrier implements the TextMapCarrier interface.
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+func (c metadataCarrier) Get(key string) string {
+	values := c.metadata.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	c.metadata.Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	out := make([]string, 0, len(*c.metadata))
+	for key := range *c.metadata {
+		out = append(out, key)
+	}
+	return out
+}
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package http provides go-kit HTTP transport options tracing the requests
+// served and sent by github.com/go-kit/kit/transport/http servers and
+// clients.
+//
+// ServerTrace extracts the trace context of incoming requests with the
+// configured propagators and starts a server span for every request. ClientTrace
+// starts a client span for every outgoing request and injects its context
+// into the request headers. The operation of the spans is stored in the
+// request context, so that endpoint spans created by otelkit.EndpointMiddleware
+// can be named after it with otelkit.WithOperationGetter(otelkit.OperationFromContext).
+package http // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit/http"
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit/http"
+
+import (
+	"context"
+	"net/http"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+)
+
+// ServerTrace returns a kithttp.ServerOption tracing the requests served by
+// a go-kit HTTP server. The span is started before the request is decoded and
+// ended once the response is written.
+func ServerTrace(options ...Option) kithttp.ServerOption {
+	cfg := newConfig(options)
+	tracer := cfg.tracer()
+
+	before := func(ctx context.Context, r *http.Request) context.Context {
+		ctx = cfg.Propagators.Extract(ctx, propagation.HeaderCarrier(r.Header))
+		operation := cfg.operation(ctx, r)
+		ctx, _ = tracer.Start(ctx, operation,
+			trace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", r)...),
+			trace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(r)...),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("", "", r)...),
+			trace.WithSpanKind(trace.SpanKindServer),
+		)
+		return otelkit.ContextWithOperation(ctx, operation)
+	}
+
+	finalizer := func(ctx context.Context, code int, r *http.Request) {
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(code)...)
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(code, trace.SpanKindServer))
+		span.End()
+	}
+
+	return func(s *kithttp.Server) {
+		kithttp.ServerBefore(before)(s)
+		kithttp.ServerFinalizer(finalizer)(s)
+	}
+}
+
+// clientSpanKey is the context key of the span started by ClientTrace.
+type clientSpanKey struct{}
+
+// ClientTrace returns a kithttp.ClientOption tracing the requests sent by a
+// go-kit HTTP client. The context of the client span is injected into the
+// request headers.
+func ClientTrace(options ...Option) kithttp.ClientOption {
+	cfg := newConfig(options)
+	tracer := cfg.tracer()
+
+	before := func(ctx context.Context, r *http.Request) context.Context {
+		operation := cfg.operation(ctx, r)
+		ctx, span := tracer.Start(ctx, operation,
+			trace.WithAttributes(semconv.HTTPClientAttributesFromHTTPRequest(r)...),
+			trace.WithSpanKind(trace.SpanKindClient),
+		)
+		cfg.Propagators.Inject(ctx, propagation.HeaderCarrier(r.Header))
+		ctx = context.WithValue(ctx, clientSpanKey{}, span)
+		return otelkit.ContextWithOperation(ctx, operation)
+	}
+
+	after := func(ctx context.Context, resp *http.Response) context.Context {
+		span, ok := ctx.Value(clientSpanKey{}).(trace.Span)
+		if !ok {
+			return ctx
+		}
+		span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(resp.StatusCode)...)
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(resp.StatusCode, trace.SpanKindClient))
+		return ctx
+	}
+
+	finalizer := func(ctx context.Context, err error) {
+		// The finalizer also runs when the request failed to be encoded,
+		// before the client span was started.
+		span, ok := ctx.Value(clientSpanKey{}).(trace.Span)
+		if !ok {
+			return
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+
+	return func(c *kithttp.Client) {
+		kithttp.ClientBefore(before)(c)
+		kithttp.ClientAfter(after)(c)
+		kithttp.ClientFinalizer(finalizer)(c)
+	}
+}
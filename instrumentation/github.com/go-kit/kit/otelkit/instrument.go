@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// Endpoint metrics.
+const (
+	EndpointDuration = "gokit.endpoint.duration" // Time taken by endpoint calls, milliseconds
+	EndpointCalls    = "gokit.endpoint.calls"    // Number of endpoint calls
+)
+
+// Endpoint metric attributes.
+const (
+	OperationKey = attribute.Key("gokit.operation")
+	OutcomeKey   = attribute.Key("gokit.outcome")
+)
+
+type instruments struct {
+	// duration is the time taken by endpoint calls.
+	duration syncfloat64.Histogram
+
+	// calls is the number of endpoint calls.
+	calls syncint64.Counter
+}
+
+// newInstruments will create instruments using a meter
+// from the given provider p.
+func newInstruments(p metric.MeterProvider) *instruments {
+	meter := p.Meter(
+		tracerName,
+		metric.WithInstrumentationVersion(SemVersion()),
+	)
+	instruments := &instruments{}
+	var err error
+
+	if instruments.duration, err = meter.SyncFloat64().Histogram(
+		EndpointDuration,
+		instrument.WithDescription("Time taken by endpoint calls"),
+		instrument.WithUnit(unit.Milliseconds),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.calls, err = meter.SyncInt64().Counter(
+		EndpointCalls,
+		instrument.WithDescription("Number of endpoint calls"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return instruments
+}
+
+// record records an endpoint call of operation started at start.
+func (i *instruments) record(ctx context.Context, operation string, outcome Outcome, start time.Time) {
+	attrs := []attribute.KeyValue{
+		OperationKey.String(operation),
+		OutcomeKey.String(string(outcome)),
+	}
+	i.calls.Add(ctx, 1, attrs...)
+	// Use floating point division here for higher precision (instead of Millisecond method).
+	i.duration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), attrs...)
+}
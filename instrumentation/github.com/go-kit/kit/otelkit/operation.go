@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+
+import "context"
+
+type operationKey struct{}
+
+// ContextWithOperation returns a copy of ctx carrying the operation name
+// determined by the transport layer. It is used by the otelkit/http and
+// otelkit/grpc transport options.
+func ContextWithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFromContext returns the operation name set by the transport layer
+// with ContextWithOperation, or operation if there is none. It can be given
+// to WithOperationGetter so that endpoint spans are named after the
+// operations of the transport spans.
+func OperationFromContext(ctx context.Context, operation string) string {
+	if op, ok := ctx.Value(operationKey{}).(string); ok && op != "" {
+		return op
+	}
+	return operation
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+// Outcome classifies the result of an endpoint call.
+type Outcome string
+
+// Endpoint call outcomes.
+const (
+	// OutcomeSuccess is the outcome of calls that succeeded.
+	OutcomeSuccess Outcome = "success"
+
+	// OutcomeBusinessError is the outcome of calls whose response carries a
+	// business failure, identified through the endpoint.Failer interface.
+	OutcomeBusinessError Outcome = "business_error"
+
+	// OutcomeError is the outcome of calls that returned an error, such as
+	// a transport or load balancing failure.
+	OutcomeError Outcome = "error"
+)
+
+// ErrorClassifier determines the outcome of an endpoint call from its
+// response and error.
+type ErrorClassifier func(ctx context.Context, response interface{}, err error) Outcome
+
+// DefaultErrorClassifier is the ErrorClassifier used when none is set with
+// WithErrorClassifier. Calls returning an error are classified as
+// OutcomeError and calls whose response is a failed endpoint.Failer as
+// OutcomeBusinessError.
+func DefaultErrorClassifier(_ context.Context, response interface{}, err error) Outcome {
+	if err != nil {
+		return OutcomeError
+	}
+	if res, ok := response.(endpoint.Failer); ok && res.Failed() != nil {
+		return OutcomeBusinessError
+	}
+	return OutcomeSuccess
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+	otelkitgrpc "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit/grpc"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func nopCodec(_ context.Context, v interface{}) (interface{}, error) {
+	return v, nil
+}
+
+// serveGRPC serves the requests to any method with the given go-kit server
+// and returns a client connection to it.
+func serveGRPC(t *testing.T, server *kitgrpc.Server) *grpc.ClientConn {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+		in := new(emptypb.Empty)
+		if err := stream.RecvMsg(in); err != nil {
+			return err
+		}
+		_, out, err := server.ServeGRPC(stream.Context(), in)
+		if err != nil {
+			return err
+		}
+		return stream.SendMsg(out)
+	}))
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestGRPCTransport(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := propagation.TraceContext{}
+
+	mw := otelkit.EndpointMiddleware(
+		otelkit.WithTracerProvider(provider),
+		otelkit.WithOperationGetter(otelkit.OperationFromContext),
+	)
+	server := kitgrpc.NewServer(
+		mw(passEndpoint),
+		nopCodec,
+		nopCodec,
+		otelkitgrpc.ServerTrace(
+			otelkitgrpc.WithTracerProvider(provider),
+			otelkitgrpc.WithPropagators(propagators),
+		),
+	)
+	conn := serveGRPC(t, server)
+
+	client := kitgrpc.NewClient(
+		conn,
+		"test.Greeter",
+		"Greet",
+		nopCodec,
+		nopCodec,
+		emptypb.Empty{},
+		otelkitgrpc.ClientTrace(
+			otelkitgrpc.WithTracerProvider(provider),
+			otelkitgrpc.WithPropagators(propagators),
+		),
+	)
+
+	_, err := client.Endpoint()(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	endpointSpan, serverSpan, clientSpan := spans[0], spans[1], spans[2]
+
+	for _, span := range []sdktrace.ReadOnlySpan{serverSpan, clientSpan} {
+		assert.Equal(t, "test.Greeter/Greet", span.Name())
+		assert.Contains(t, span.Attributes(), semconv.RPCSystemGRPC)
+		assert.Contains(t, span.Attributes(), semconv.RPCServiceKey.String("test.Greeter"))
+		assert.Contains(t, span.Attributes(), semconv.RPCMethodKey.String("Greet"))
+		assert.Contains(t, span.Attributes(), semconv.RPCGRPCStatusCodeKey.Int64(int64(grpccodes.OK)))
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	}
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+	assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+	assert.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID())
+
+	assert.Equal(t, "test.Greeter/Greet", endpointSpan.Name())
+	assert.Equal(t, serverSpan.SpanContext().SpanID(), endpointSpan.Parent().SpanID())
+}
+
+func TestGRPCTransportError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	server := kitgrpc.NewServer(
+		passEndpoint,
+		func(context.Context, interface{}) (interface{}, error) {
+			return status.Error(grpccodes.InvalidArgument, "bad request"), nil
+		},
+		nopCodec,
+		otelkitgrpc.ServerTrace(
+			otelkitgrpc.WithTracerProvider(provider),
+			otelkitgrpc.WithOperation("greet"),
+		),
+	)
+
+	_, _, err := server.ServeGRPC(context.Background(), &emptypb.Empty{})
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "greet", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.RPCGRPCStatusCodeKey.Int64(int64(grpccodes.InvalidArgument)))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "bad request", spans[0].Status().Description)
+}
+
+func TestGRPCClientEncodeError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	client := kitgrpc.NewClient(
+		nil,
+		"test.Greeter",
+		"Greet",
+		func(context.Context, interface{}) (interface{}, error) {
+			return nil, errors.New("encode failed")
+		},
+		nopCodec,
+		emptypb.Empty{},
+		otelkitgrpc.ClientTrace(otelkitgrpc.WithTracerProvider(provider)),
+	)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, err := client.Endpoint()(ctx, &emptypb.Empty{})
+	require.Error(t, err)
+
+	assert.Empty(t, sr.Ended(), "the span of the caller must not be ended")
+	assert.True(t, parent.IsRecording())
+	parent.End()
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+	otelkithttp "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit/http"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHTTPTransport(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := propagation.TraceContext{}
+
+	mw := otelkit.EndpointMiddleware(
+		otelkit.WithTracerProvider(provider),
+		otelkit.WithOperationGetter(otelkit.OperationFromContext),
+	)
+	server := kithttp.NewServer(
+		mw(passEndpoint),
+		func(_ context.Context, r *http.Request) (interface{}, error) {
+			var req string
+			err := json.NewDecoder(r.Body).Decode(&req)
+			return req, err
+		},
+		kithttp.EncodeJSONResponse,
+		otelkithttp.ServerTrace(
+			otelkithttp.WithTracerProvider(provider),
+			otelkithttp.WithPropagators(propagators),
+			otelkithttp.WithOperation("greet"),
+		),
+	)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	client := kithttp.NewClient(
+		http.MethodPost,
+		u,
+		kithttp.EncodeJSONRequest,
+		func(_ context.Context, resp *http.Response) (interface{}, error) {
+			var res string
+			err := json.NewDecoder(resp.Body).Decode(&res)
+			return res, err
+		},
+		otelkithttp.ClientTrace(
+			otelkithttp.WithTracerProvider(provider),
+			otelkithttp.WithPropagators(propagators),
+		),
+	)
+
+	res, err := client.Endpoint()(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	endpointSpan, serverSpan, clientSpan := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, "HTTP POST", clientSpan.Name())
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Contains(t, clientSpan.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusOK))
+
+	assert.Equal(t, "greet", serverSpan.Name())
+	assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+	assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+	assert.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID())
+	assert.Contains(t, serverSpan.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusOK))
+	assert.Equal(t, codes.Unset, serverSpan.Status().Code)
+
+	assert.Equal(t, "greet", endpointSpan.Name())
+	assert.Equal(t, serverSpan.SpanContext().SpanID(), endpointSpan.Parent().SpanID())
+}
+
+func TestHTTPTransportError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	server := kithttp.NewServer(
+		passEndpoint,
+		func(context.Context, *http.Request) (interface{}, error) {
+			return customError{"bad request"}, nil
+		},
+		kithttp.EncodeJSONResponse,
+		otelkithttp.ServerTrace(otelkithttp.WithTracerProvider(provider)),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, r)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "HTTP GET", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusInternalServerError))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestHTTPClientEncodeError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	client := kithttp.NewClient(
+		http.MethodPost,
+		&url.URL{},
+		func(context.Context, *http.Request, interface{}) error {
+			return errors.New("encode failed")
+		},
+		func(context.Context, *http.Response) (interface{}, error) {
+			return nil, nil
+		},
+		otelkithttp.ClientTrace(otelkithttp.WithTracerProvider(provider)),
+	)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, err := client.Endpoint()(ctx, "hello")
+	require.Error(t, err)
+
+	assert.Empty(t, sr.Ended(), "the span of the caller must not be ended")
+	assert.True(t, parent.IsRecording())
+	parent.End()
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// collectCalls returns the number of endpoint calls recorded by outcome.
+func collectCalls(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	calls := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			assert.Equal(t, otelkit.EndpointCalls, m.Name)
+			for _, dp := range data.DataPoints {
+				operation, _ := dp.Attributes.Value(otelkit.OperationKey)
+				assert.Equal(t, "operation", operation.AsString())
+				outcome, _ := dp.Attributes.Value(otelkit.OutcomeKey)
+				calls[outcome.AsString()] = dp.Value
+			}
+		case metricdata.Histogram:
+			assert.Equal(t, otelkit.EndpointDuration, m.Name)
+			assert.Len(t, data.DataPoints, 3)
+		}
+	}
+	return calls
+}
+
+func TestEndpointMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mw := otelkit.EndpointMiddleware(
+		otelkit.WithMeterProvider(meterProvider),
+		otelkit.WithOperation("operation"),
+	)
+	e := mw(passEndpoint)
+
+	_, _ = e(context.Background(), nil)
+	_, _ = e(context.Background(), nil)
+	_, _ = e(context.Background(), failedResponse{err: customError{"failure"}})
+	_, _ = e(context.Background(), errors.New("dummy"))
+
+	assert.Equal(t, map[string]int64{
+		string(otelkit.OutcomeSuccess):       2,
+		string(otelkit.OutcomeBusinessError): 1,
+		string(otelkit.OutcomeError):         1,
+	}, collectCalls(t, reader))
+}
+
+func TestErrorClassifier(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	expected := customError{"expected"}
+	mw := otelkit.EndpointMiddleware(
+		otelkit.WithTracerProvider(provider),
+		otelkit.WithMeterProvider(meterProvider),
+		otelkit.WithOperation("operation"),
+		otelkit.WithErrorClassifier(func(ctx context.Context, response interface{}, err error) otelkit.Outcome {
+			if res, ok := response.(failedResponse); ok && res.err == expected {
+				return otelkit.OutcomeSuccess
+			}
+			if err == expected {
+				return otelkit.OutcomeBusinessError
+			}
+			return otelkit.DefaultErrorClassifier(ctx, response, err)
+		}),
+	)
+	e := mw(passEndpoint)
+
+	_, _ = e(context.Background(), failedResponse{err: expected})
+	_, _ = e(context.Background(), expected)
+	_, _ = e(context.Background(), errors.New("dummy"))
+
+	assert.Equal(t, map[string]int64{
+		string(otelkit.OutcomeSuccess):       1,
+		string(otelkit.OutcomeBusinessError): 1,
+		string(otelkit.OutcomeError):         1,
+	}, collectCalls(t, reader))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+
+	// Errors are still recorded, only the span status follows the outcome.
+	for _, span := range spans {
+		require.Len(t, span.Events(), 1)
+		assert.Equal(t, "exception", span.Events()[0].Name)
+	}
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.Contains(t, spans[2].Events()[0].Attributes, attribute.String("exception.message", "dummy"))
+}
@@ -32,6 +32,8 @@ type config struct {
 	queryObserver     gocql.QueryObserver
 	batchObserver     gocql.BatchObserver
 	connectObserver   gocql.ConnectObserver
+	capture           captureConfig
+	serverTracing     bool
 }
 
 // Option applies a configuration option.
@@ -117,6 +119,53 @@ func WithConnectInstrumentation(enabled bool) Option {
 	})
 }
 
+// WithSanitizedStatements will enable and disable the replacement of the
+// literal values of statements with ?, in span names, the db.statement
+// attribute and metric labels. Defaults to disabled.
+func WithSanitizedStatements(enabled bool) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.capture.sanitizeStatements = enabled
+	})
+}
+
+// WithBoundValues will enable and disable recording the values bound to
+// statements, and the paging state of queries. Values are obfuscated and
+// capped to the size set with WithValuesMaxSize. They are never recorded when
+// HS_METADATA_ONLY is set. Defaults to disabled.
+func WithBoundValues(enabled bool) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.capture.boundValues = enabled
+	})
+}
+
+// WithBatchStatements will enable and disable recording each statement
+// contained within a batch query, along with its bound values when
+// WithBoundValues is enabled. Defaults to disabled.
+func WithBatchStatements(enabled bool) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.capture.batchStatements = enabled
+	})
+}
+
+// WithValuesMaxSize will set the maximum size, in bytes, of the recorded
+// bound values of a statement. Defaults to DefaultValuesMaxSize.
+func WithValuesMaxSize(size int) Option {
+	return optionFunc(func(cfg *config) {
+		if size > 0 {
+			cfg.capture.valuesMaxSize = size
+		}
+	})
+}
+
+// WithServerTracing will enable and disable recording the Cassandra
+// server-side traces of queries prepared with TraceQuery as child spans of
+// the query spans. Defaults to disabled.
+func WithServerTracing(enabled bool) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.serverTracing = enabled
+	})
+}
+
 func newConfig(options ...Option) *config {
 	cfg := &config{
 		tracerProvider:    otel.GetTracerProvider(),
@@ -124,6 +173,9 @@ func newConfig(options ...Option) *config {
 		instrumentQuery:   true,
 		instrumentBatch:   true,
 		instrumentConnect: true,
+		capture: captureConfig{
+			valuesMaxSize: DefaultValuesMaxSize,
+		},
 	}
 
 	for _, apply := range options {
@@ -33,17 +33,24 @@ func NewSessionWithTracing(ctx context.Context, cluster *gocql.ClusterConfig, op
 		internal.InstrumentationName,
 		trace.WithInstrumentationVersion(SemVersion()),
 	)
+	var st *serverTracing
+	if cfg.serverTracing {
+		st = newServerTracing(tracer)
+	}
 	cluster.QueryObserver = &OTelQueryObserver{
-		enabled:  cfg.instrumentQuery,
-		observer: cfg.queryObserver,
-		tracer:   tracer,
-		inst:     instruments,
+		enabled:       cfg.instrumentQuery,
+		observer:      cfg.queryObserver,
+		tracer:        tracer,
+		inst:          instruments,
+		capture:       cfg.capture,
+		serverTracing: st,
 	}
 	cluster.BatchObserver = &OTelBatchObserver{
 		enabled:  cfg.instrumentBatch,
 		observer: cfg.batchObserver,
 		tracer:   tracer,
 		inst:     instruments,
+		capture:  cfg.capture,
 	}
 	cluster.ConnectObserver = &OTelConnectObserver{
 		ctx:      ctx,
@@ -52,5 +59,9 @@ func NewSessionWithTracing(ctx context.Context, cluster *gocql.ClusterConfig, op
 		tracer:   tracer,
 		inst:     instruments,
 	}
-	return cluster.CreateSession()
+	session, err := cluster.CreateSession()
+	if err == nil && st != nil {
+		st.start(session)
+	}
+	return session, err
 }
@@ -52,6 +52,51 @@ const (
 	// made for the query in question.
 	CassQueryAttemptsKey = attribute.Key("db.cassandra.attempts")
 
+	// CassBoundValuesKey is the key for the span attribute describing the
+	// values bound to the statement of a query.
+	CassBoundValuesKey = attribute.Key("db.cassandra.values")
+
+	// CassBatchStatementsKey is the key for the span attribute describing
+	// the statements contained within the batch statement.
+	CassBatchStatementsKey = attribute.Key("db.cassandra.batch.statements")
+
+	// CassBatchValuesKey is the key for the span attribute describing the
+	// values bound to each statement contained within the batch statement.
+	CassBatchValuesKey = attribute.Key("db.cassandra.batch.values")
+
+	// CassPageContinuedKey is the key for the span attribute describing
+	// whether a query fetches a page following a previous one.
+	CassPageContinuedKey = attribute.Key("db.cassandra.page.continued")
+
+	// CassPageStateKey is the key for the span attribute describing the
+	// paging state a query resumes from.
+	CassPageStateKey = attribute.Key("db.cassandra.page.state")
+
+	// CassTraceSessionIDKey is the key for the span attribute describing
+	// the id of a server-side trace session.
+	CassTraceSessionIDKey = attribute.Key("db.cassandra.trace.session_id")
+
+	// CassTraceCoordinatorKey is the key for the span attribute describing
+	// the address of the coordinator of a traced request.
+	CassTraceCoordinatorKey = attribute.Key("db.cassandra.trace.coordinator")
+
+	// CassTraceRequestKey is the key for the span attribute describing
+	// the kind of a traced request.
+	CassTraceRequestKey = attribute.Key("db.cassandra.trace.request")
+
+	// CassTraceSourceKey is the key for the event attribute describing the
+	// address of the node a step of a traced request ran on.
+	CassTraceSourceKey = attribute.Key("db.cassandra.trace.source")
+
+	// CassTraceSourceElapsedKey is the key for the event attribute describing
+	// the time elapsed on the node when a step of a traced request ran, in
+	// microseconds.
+	CassTraceSourceElapsedKey = attribute.Key("db.cassandra.trace.source_elapsed")
+
+	// CassTraceThreadKey is the key for the event attribute describing the
+	// thread a step of a traced request ran on.
+	CassTraceThreadKey = attribute.Key("db.cassandra.trace.thread")
+
 	// CassBatchQueryName is the batch operation span name.
 	CassBatchQueryName = "Batch Query"
 	// CassConnectName is the connect operation span name.
@@ -172,3 +217,75 @@ func HostOrIP(hostnameAndPort string) attribute.KeyValue {
 	}
 	return CassPeerName(hostname)
 }
+
+// CassBoundValues returns the KeyValue pair of the values bound to
+// the statement of a query.
+func CassBoundValues(values string) attribute.KeyValue {
+	return CassBoundValuesKey.String(values)
+}
+
+// CassBatchStatements returns the KeyValue pair of the statements
+// contained within a batch query.
+func CassBatchStatements(stmts []string) attribute.KeyValue {
+	return CassBatchStatementsKey.StringSlice(stmts)
+}
+
+// CassBatchValues returns the KeyValue pair of the values bound to
+// each statement contained within a batch query.
+func CassBatchValues(values []string) attribute.KeyValue {
+	return CassBatchValuesKey.StringSlice(values)
+}
+
+// CassPageSize returns the page size of a query as a semconv
+// KeyValue pair (db.cassandra.page_size).
+func CassPageSize(size int) attribute.KeyValue {
+	return semconv.DBCassandraPageSizeKey.Int(size)
+}
+
+// CassPageContinued returns the KeyValue pair describing whether
+// a query fetches a page following a previous one.
+func CassPageContinued(continued bool) attribute.KeyValue {
+	return CassPageContinuedKey.Bool(continued)
+}
+
+// CassPageState returns the KeyValue pair of the paging state
+// a query resumes from.
+func CassPageState(state string) attribute.KeyValue {
+	return CassPageStateKey.String(state)
+}
+
+// CassTraceSessionID returns the KeyValue pair of the id of
+// a server-side trace session.
+func CassTraceSessionID(id string) attribute.KeyValue {
+	return CassTraceSessionIDKey.String(id)
+}
+
+// CassTraceCoordinator returns the KeyValue pair of the address of
+// the coordinator of a traced request.
+func CassTraceCoordinator(address string) attribute.KeyValue {
+	return CassTraceCoordinatorKey.String(address)
+}
+
+// CassTraceRequest returns the KeyValue pair of the kind of
+// a traced request.
+func CassTraceRequest(request string) attribute.KeyValue {
+	return CassTraceRequestKey.String(request)
+}
+
+// CassTraceSource returns the KeyValue pair of the address of the node
+// a step of a traced request ran on.
+func CassTraceSource(address string) attribute.KeyValue {
+	return CassTraceSourceKey.String(address)
+}
+
+// CassTraceSourceElapsed returns the KeyValue pair of the time elapsed,
+// in microseconds, on the node when a step of a traced request ran.
+func CassTraceSourceElapsed(elapsed int) attribute.KeyValue {
+	return CassTraceSourceElapsedKey.Int(elapsed)
+}
+
+// CassTraceThread returns the KeyValue pair of the thread a step
+// of a traced request ran on.
+func CassTraceThread(thread string) attribute.KeyValue {
+	return CassTraceThreadKey.String(thread)
+}
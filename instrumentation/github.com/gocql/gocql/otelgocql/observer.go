@@ -28,10 +28,12 @@ import (
 // OTelQueryObserver implements the gocql.QueryObserver interface
 // to provide instrumentation to gocql queries.
 type OTelQueryObserver struct {
-	enabled  bool
-	observer gocql.QueryObserver
-	tracer   trace.Tracer
-	inst     *instruments
+	enabled       bool
+	observer      gocql.QueryObserver
+	tracer        trace.Tracer
+	inst          *instruments
+	capture       captureConfig
+	serverTracing *serverTracing
 }
 
 // OTelBatchObserver implements the gocql.BatchObserver interface
@@ -41,6 +43,7 @@ type OTelBatchObserver struct {
 	observer gocql.BatchObserver
 	tracer   trace.Tracer
 	inst     *instruments
+	capture  captureConfig
 }
 
 // OTelConnectObserver implements the gocql.ConnectObserver interface
@@ -57,21 +60,26 @@ type OTelConnectObserver struct {
 
 // ObserveQuery is called once per query, and provides instrumentation for it.
 func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocql.ObservedQuery) {
-	if o.enabled {
+	if o.enabled && !suppressed(ctx) {
 		host := observedQuery.Host
 		keyspace := observedQuery.Keyspace
+		statement := o.capture.statement(observedQuery.Statement)
 		inst := o.inst
 
 		attributes := includeKeyValues(host,
 			internal.CassKeyspace(keyspace),
-			internal.CassStatement(observedQuery.Statement),
+			internal.CassStatement(statement),
 			internal.CassRowsReturned(observedQuery.Rows),
 			internal.CassQueryAttempts(observedQuery.Metrics.Attempts),
 		)
+		if o.capture.captureValues() && len(observedQuery.Values) > 0 {
+			attributes = append(attributes, internal.CassBoundValues(o.capture.values(observedQuery.Values)))
+		}
+		attributes = append(attributes, o.capture.pagingAttributes(ctx)...)
 
 		ctx, span := o.tracer.Start(
 			ctx,
-			observedQuery.Statement,
+			statement,
 			trace.WithTimestamp(observedQuery.Start),
 			trace.WithAttributes(attributes...),
 			trace.WithSpanKind(trace.SpanKindClient),
@@ -84,7 +92,7 @@ func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocq
 				1,
 				includeKeyValues(host,
 					internal.CassKeyspace(keyspace),
-					internal.CassStatement(observedQuery.Statement),
+					internal.CassStatement(statement),
 					internal.CassErrMsg(observedQuery.Err.Error()),
 				)...,
 			)
@@ -94,11 +102,15 @@ func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocq
 				1,
 				includeKeyValues(host,
 					internal.CassKeyspace(keyspace),
-					internal.CassStatement(observedQuery.Statement),
+					internal.CassStatement(statement),
 				)...,
 			)
 		}
 
+		if o.serverTracing != nil {
+			o.serverTracing.record(ctx, span)
+		}
+
 		span.End(trace.WithTimestamp(observedQuery.End))
 
 		inst.queryRows.Record(
@@ -130,6 +142,9 @@ func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocq
 			internal.CassBatchQueryOperation(),
 			internal.CassBatchQueries(len(observedBatch.Statements)),
 		)
+		if o.capture.batchStatements {
+			attributes = append(attributes, o.capture.batchAttributes(observedBatch)...)
+		}
 
 		ctx, span := o.tracer.Start(
 			ctx,
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgocql // import "go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql"
+
+import (
+	"context"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql/internal"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServerTraceSpanName is the name of the spans describing Cassandra
+// server-side query traces.
+const ServerTraceSpanName = "Cassandra Server Trace"
+
+// serverTraceDelays are the delays to wait for before reading a server-side
+// trace. Cassandra writes traces asynchronously, so the read is retried
+// until the trace session is complete.
+var serverTraceDelays = []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second}
+
+// serverTraceQueueSize bounds the number of server-side traces waiting to be
+// read. Traces are dropped when the queue is full.
+const serverTraceQueueSize = 64
+
+// serverTraceCheckInterval is the interval at which the worker reading
+// server-side traces checks whether the session was closed.
+var serverTraceCheckInterval = time.Second
+
+type serverTraceKey struct{}
+
+type suppressKey struct{}
+
+// serverTrace is the gocql.Tracer set on queries traced with TraceQuery. It
+// holds the id of the last server-side trace of the query until it is read
+// by the query observer.
+type serverTrace struct {
+	mu      sync.Mutex
+	traceID []byte
+}
+
+// Trace implements gocql.Tracer.
+func (t *serverTrace) Trace(traceID []byte) {
+	t.mu.Lock()
+	t.traceID = append([]byte(nil), traceID...)
+	t.mu.Unlock()
+}
+
+// take returns and forgets the id of the last server-side trace.
+func (t *serverTrace) take() []byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.traceID
+	t.traceID = nil
+	return id
+}
+
+// TraceQuery enables Cassandra server-side tracing of q if the span in its
+// context, set with gocql.Query.WithContext, is sampled. When the session
+// was created with WithServerTracing, every traced execution of q gets a
+// child span built from the system_traces tables, with the coordinator, the
+// duration of the request and an event for each step, carrying the replica
+// it ran on and the elapsed time.
+func TraceQuery(q *gocql.Query) *gocql.Query {
+	ctx := q.Context()
+	if !trace.SpanContextFromContext(ctx).IsSampled() {
+		return q
+	}
+	t := &serverTrace{}
+	return q.WithContext(context.WithValue(ctx, serverTraceKey{}, t)).Trace(t)
+}
+
+// suppressed reports whether queries observed with ctx are made by the
+// instrumentation itself and should not be traced.
+func suppressed(ctx context.Context) bool {
+	return ctx.Value(suppressKey{}) != nil
+}
+
+// serverTracing reads server-side traces and records them as spans. Traces
+// are read one at a time by a worker that runs until the session is closed.
+type serverTracing struct {
+	tracer trace.Tracer
+
+	mu      sync.Mutex
+	session *gocql.Session
+
+	requests chan serverTraceRequest
+	// stop is closed once the worker has stopped.
+	stop chan struct{}
+}
+
+type serverTraceRequest struct {
+	parent  context.Context
+	traceID []byte
+}
+
+func newServerTracing(tracer trace.Tracer) *serverTracing {
+	return &serverTracing{
+		tracer:   tracer,
+		requests: make(chan serverTraceRequest, serverTraceQueueSize),
+		stop:     make(chan struct{}),
+	}
+}
+
+// start sets the session server-side traces are read with and starts the
+// worker reading them.
+func (s *serverTracing) start(session *gocql.Session) {
+	s.mu.Lock()
+	s.session = session
+	s.mu.Unlock()
+
+	go s.run(session)
+}
+
+// started reports whether the worker has been started and has not stopped.
+func (s *serverTracing) started() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == nil {
+		return false
+	}
+	select {
+	case <-s.stop:
+		return false
+	default:
+		return true
+	}
+}
+
+// run reads queued server-side traces until the session is closed.
+func (s *serverTracing) run(session *gocql.Session) {
+	defer close(s.stop)
+
+	ticker := time.NewTicker(serverTraceCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case req := <-s.requests:
+			s.read(session, req.parent, req.traceID)
+		case <-ticker.C:
+		}
+		if session.Closed() {
+			return
+		}
+	}
+}
+
+// record queues the server-side trace of the query observed with ctx, if
+// any, to be read and recorded as a child of the query span.
+func (s *serverTracing) record(ctx context.Context, span trace.Span) {
+	t, ok := ctx.Value(serverTraceKey{}).(*serverTrace)
+	if !ok {
+		return
+	}
+	traceID := t.take()
+	if len(traceID) == 0 || !s.started() {
+		return
+	}
+
+	req := serverTraceRequest{
+		parent:  trace.ContextWithSpan(context.Background(), span),
+		traceID: traceID,
+	}
+	select {
+	case s.requests <- req:
+	default:
+		// Do not hold up queries when traces cannot be read fast enough.
+	}
+}
+
+func (s *serverTracing) read(session *gocql.Session, parent context.Context, traceID []byte) {
+	ctx := context.WithValue(context.Background(), suppressKey{}, struct{}{})
+
+	var (
+		coordinator net.IP
+		duration    int
+		request     string
+		startedAt   time.Time
+	)
+	for _, delay := range serverTraceDelays {
+		time.Sleep(delay)
+		if session.Closed() {
+			return
+		}
+		err := session.Query(
+			`SELECT coordinator, duration, request, started_at FROM system_traces.sessions WHERE session_id = ?`, traceID,
+		).WithContext(ctx).Consistency(gocql.One).Scan(&coordinator, &duration, &request, &startedAt)
+		if err == nil && duration > 0 {
+			break
+		}
+		if err == gocql.ErrSessionClosed {
+			return
+		}
+		if err != nil && err != gocql.ErrNotFound {
+			log.Printf("failed to read cassandra server trace, %v", err)
+			return
+		}
+	}
+	if duration == 0 {
+		return
+	}
+
+	sessionID, _ := gocql.UUIDFromBytes(traceID)
+	_, span := s.tracer.Start(parent, ServerTraceSpanName,
+		trace.WithTimestamp(startedAt),
+		trace.WithAttributes(
+			internal.CassTraceSessionID(sessionID.String()),
+			internal.CassTraceCoordinator(coordinator.String()),
+			internal.CassTraceRequest(request),
+		),
+	)
+
+	var (
+		eventID       gocql.UUID
+		activity      string
+		source        net.IP
+		sourceElapsed int
+		thread        string
+	)
+	iter := session.Query(
+		`SELECT event_id, activity, source, source_elapsed, thread FROM system_traces.events WHERE session_id = ?`, traceID,
+	).WithContext(ctx).Consistency(gocql.One).Iter()
+	for iter.Scan(&eventID, &activity, &source, &sourceElapsed, &thread) {
+		span.AddEvent(activity,
+			trace.WithTimestamp(eventID.Time()),
+			trace.WithAttributes(
+				internal.CassTraceSource(source.String()),
+				internal.CassTraceSourceElapsed(sourceElapsed),
+				internal.CassTraceThread(thread),
+			),
+		)
+	}
+	if err := iter.Close(); err != nil {
+		log.Printf("failed to read cassandra server trace events, %v", err)
+	}
+
+	span.End(trace.WithTimestamp(startedAt.Add(time.Duration(duration) * time.Microsecond)))
+}
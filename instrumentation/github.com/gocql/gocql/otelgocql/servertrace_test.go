@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgocql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceQuery(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+
+	unsampled := trace.ContextWithSpanContext(context.Background(), sc)
+	q := TraceQuery((&gocql.Query{}).WithContext(unsampled))
+	assert.Nil(t, q.Context().Value(serverTraceKey{}))
+
+	sampled := trace.ContextWithSpanContext(context.Background(), sc.WithTraceFlags(trace.FlagsSampled))
+	q = TraceQuery((&gocql.Query{}).WithContext(sampled))
+	st, ok := q.Context().Value(serverTraceKey{}).(*serverTrace)
+	assert.True(t, ok)
+
+	st.Trace([]byte{0x01, 0x02})
+	assert.Equal(t, []byte{0x01, 0x02}, st.take())
+	assert.Empty(t, st.take())
+}
+
+func TestSuppressed(t *testing.T) {
+	assert.False(t, suppressed(context.Background()))
+	assert.True(t, suppressed(context.WithValue(context.Background(), suppressKey{}, struct{}{})))
+}
+
+func TestServerTracingRecord(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	span := trace.SpanFromContext(trace.ContextWithSpanContext(context.Background(), sc))
+	traced := func() context.Context {
+		st := &serverTrace{}
+		st.Trace([]byte{0x01})
+		return context.WithValue(context.Background(), serverTraceKey{}, st)
+	}
+
+	s := newServerTracing(trace.NewNoopTracerProvider().Tracer(""))
+	s.record(traced(), span)
+	assert.Empty(t, s.requests, "traces must not be queued before the session is set")
+
+	s.session = &gocql.Session{}
+	for i := 0; i < serverTraceQueueSize+1; i++ {
+		s.record(traced(), span)
+	}
+	assert.Len(t, s.requests, serverTraceQueueSize)
+
+	close(s.stop)
+	<-s.requests
+	s.record(traced(), span)
+	assert.Len(t, s.requests, serverTraceQueueSize-1, "traces must not be queued once the worker stopped")
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgocql // import "go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql"
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/gocql/gocql"
+	datautils "github.com/helios/go-sdk/data-utils"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql/internal"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultValuesMaxSize is the maximum size, in bytes, of the recorded bound
+// values of a statement when no size is configured with WithValuesMaxSize.
+const DefaultValuesMaxSize = 4096
+
+// captureConfig holds the options describing what is recorded about the
+// statements run by a session.
+type captureConfig struct {
+	sanitizeStatements bool
+	boundValues        bool
+	batchStatements    bool
+	valuesMaxSize      int
+}
+
+// cqlLiteral matches CQL constants: strings, uuids, blobs, numbers and
+// booleans. Identifiers, keywords and bind markers are left untouched.
+var cqlLiteral = regexp.MustCompile(`'(?:[^']|'')*'|\$\$(?:.|\n)*?\$\$|\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b|\b0[xX][0-9a-fA-F]*\b|-?\b\d+(?:\.\d+)?(?:[eE][+-]?\d+)?\b|\b(?i:true|false|nan|infinity)\b`)
+
+// sanitizeStatement replaces the literal values of a CQL statement with ?.
+func sanitizeStatement(stmt string) string {
+	return cqlLiteral.ReplaceAllString(stmt, "?")
+}
+
+// statement returns stmt as it should be recorded.
+func (c captureConfig) statement(stmt string) string {
+	if c.sanitizeStatements {
+		return sanitizeStatement(stmt)
+	}
+	return stmt
+}
+
+// captureValues reports whether bound values should be recorded. Capture is
+// disabled altogether when HS_METADATA_ONLY is set.
+func (c captureConfig) captureValues() bool {
+	return c.boundValues && os.Getenv("HS_METADATA_ONLY") != "true"
+}
+
+// values formats bound values as a JSON array, obfuscated and truncated to
+// the configured maximum size.
+func (c captureConfig) values(values []interface{}) string {
+	b, err := json.Marshal(values)
+	if err != nil {
+		b = []byte(fmt.Sprint(values...))
+	}
+	attr := datautils.ObfuscateAttributeValue(internal.CassBoundValues(string(b)))
+	return truncate(attr.Value.AsString(), c.valuesMaxSize)
+}
+
+// truncate cuts s to at most maxSize bytes on a UTF-8 boundary.
+func truncate(s string, maxSize int) string {
+	if maxSize <= 0 {
+		maxSize = DefaultValuesMaxSize
+	}
+	if len(s) <= maxSize {
+		return s
+	}
+	cut := maxSize
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+type pagingKey struct{}
+
+type paging struct {
+	pageSize  int
+	pageState []byte
+}
+
+// ContextWithPaging returns a copy of ctx carrying the paging configuration
+// of a query. Queries observed with this context, set with
+// gocql.Query.WithContext, have their page size recorded, along with whether
+// they continue a previous page. The paging state itself is recorded, hex
+// encoded and size-capped, when bound values are recorded.
+//
+// gocql does not expose the paging configuration of a query to observers,
+// which is why it has to be provided this way.
+func ContextWithPaging(ctx context.Context, pageSize int, pageState []byte) context.Context {
+	return context.WithValue(ctx, pagingKey{}, paging{pageSize: pageSize, pageState: pageState})
+}
+
+// pagingAttributes returns the paging attributes of the query observed
+// with ctx.
+func (c captureConfig) pagingAttributes(ctx context.Context) []attribute.KeyValue {
+	p, ok := ctx.Value(pagingKey{}).(paging)
+	if !ok {
+		return nil
+	}
+
+	attrs := []attribute.KeyValue{
+		internal.CassPageContinued(len(p.pageState) > 0),
+	}
+	if p.pageSize > 0 {
+		attrs = append(attrs, internal.CassPageSize(p.pageSize))
+	}
+	if len(p.pageState) > 0 && c.captureValues() {
+		attrs = append(attrs, internal.CassPageState(truncate(hex.EncodeToString(p.pageState), c.valuesMaxSize)))
+	}
+	return attrs
+}
+
+// batchAttributes returns the attributes describing each statement contained
+// within a batch query.
+func (c captureConfig) batchAttributes(batch gocql.ObservedBatch) []attribute.KeyValue {
+	stmts := make([]string, len(batch.Statements))
+	for i, stmt := range batch.Statements {
+		stmts[i] = c.statement(stmt)
+	}
+	attrs := []attribute.KeyValue{internal.CassBatchStatements(stmts)}
+
+	if c.captureValues() && len(batch.Values) > 0 {
+		values := make([]string, len(batch.Values))
+		for i, v := range batch.Values {
+			values[i] = c.values(v)
+		}
+		attrs = append(attrs, internal.CassBatchValues(values))
+	}
+	return attrs
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgocql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql/internal"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+func TestSanitizeStatement(t *testing.T) {
+	testCases := []struct {
+		stmt string
+		want string
+	}{
+		{
+			stmt: "SELECT * FROM book WHERE id = ?",
+			want: "SELECT * FROM book WHERE id = ?",
+		},
+		{
+			stmt: "INSERT INTO book (id, title, year) VALUES (123e4567-e89b-12d3-a456-426614174000, 'It''s mine', 2020)",
+			want: "INSERT INTO book (id, title, year) VALUES (?, ?, ?)",
+		},
+		{
+			stmt: "UPDATE t1 SET price = -1.5e3, tags = ['a', 'b'], cover = 0xCAFE, sold = true WHERE id = 42",
+			want: "UPDATE t1 SET price = ?, tags = [?, ?], cover = ?, sold = ? WHERE id = ?",
+		},
+		{
+			stmt: "SELECT col2 FROM ks1.table3 LIMIT 10",
+			want: "SELECT col2 FROM ks1.table3 LIMIT ?",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, sanitizeStatement(tc.stmt))
+	}
+}
+
+func TestValues(t *testing.T) {
+	cfg := newConfig(WithBoundValues(true), WithValuesMaxSize(16))
+	require.True(t, cfg.capture.captureValues())
+
+	assert.Equal(t, `["a",1,true]`, cfg.capture.values([]interface{}{"a", 1, true}))
+	assert.Len(t, cfg.capture.values([]interface{}{strings.Repeat("v", 64)}), 16)
+
+	t.Setenv("HS_METADATA_ONLY", "true")
+	assert.False(t, cfg.capture.captureValues())
+}
+
+func TestBatchAttributes(t *testing.T) {
+	cfg := newConfig(WithSanitizedStatements(true), WithBatchStatements(true), WithBoundValues(true))
+
+	attrs := cfg.capture.batchAttributes(gocql.ObservedBatch{
+		Statements: []string{"INSERT INTO book (id) VALUES (?)", "DELETE FROM book WHERE id = 1"},
+		Values:     [][]interface{}{{1}, {}},
+	})
+	assert.Equal(t, internal.CassBatchStatements([]string{"INSERT INTO book (id) VALUES (?)", "DELETE FROM book WHERE id = ?"}), attrs[0])
+	assert.Equal(t, internal.CassBatchValues([]string{"[1]", "[]"}), attrs[1])
+}
+
+func TestPagingAttributes(t *testing.T) {
+	cfg := newConfig()
+	assert.Empty(t, cfg.capture.pagingAttributes(context.Background()))
+
+	ctx := ContextWithPaging(context.Background(), 100, []byte{0x01, 0x02})
+	attrs := cfg.capture.pagingAttributes(ctx)
+	assert.Contains(t, attrs, internal.CassPageContinued(true))
+	assert.Contains(t, attrs, semconv.DBCassandraPageSizeKey.Int(100))
+	assert.NotContains(t, attrs, internal.CassPageState("0102"))
+
+	cfg = newConfig(WithBoundValues(true))
+	assert.Contains(t, cfg.capture.pagingAttributes(ctx), internal.CassPageState("0102"))
+}
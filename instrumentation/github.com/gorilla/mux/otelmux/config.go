@@ -17,17 +17,34 @@ package otelmux // import "go.opentelemetry.io/contrib/instrumentation/github.co
 import (
 	"net/http"
 
+	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/gorilla/mux/otelmux/internal/httpserver"
+
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
+// RouteParamKeyPrefix is the prefix of the attributes recording the route
+// variables selected with WithPathParams. It is followed by the variable name.
+const RouteParamKeyPrefix = httpserver.RouteParamKeyPrefix
+
 // config is used to configure the mux middleware.
 type config struct {
 	TracerProvider    oteltrace.TracerProvider
+	MeterProvider     metric.MeterProvider
 	Propagators       propagation.TextMapPropagator
 	spanNameFormatter func(string, *http.Request) string
+	Filters           []Filter
+	PublicEndpoint    bool
+	PublicEndpointFn  func(*http.Request) bool
+	SpanStartOptions  []oteltrace.SpanStartOption
+	PathParams        httpserver.PathParams
 }
 
+// Filter is a predicate used to determine whether a given http.request should
+// be traced. A Filter must return true if the request should be traced.
+type Filter func(*http.Request) bool
+
 // Option specifies instrumentation configuration options.
 type Option interface {
 	apply(*config)
@@ -60,6 +77,16 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithSpanNameFormatter specifies a function to use for generating a custom span
 // name. By default, the route name (path template or regexp) is used. The route
 // name is provided so you can use it in the span name without needing to
@@ -69,3 +96,63 @@ func WithSpanNameFormatter(fn func(routeName string, r *http.Request) string) Op
 		cfg.spanNameFormatter = fn
 	})
 }
+
+// WithFilter adds a filter to the list of filters used by the middleware.
+// If any filter indicates to exclude a request then the request will not be
+// traced. All filters must allow a request to be traced for a Span to be created.
+// If no filters are provided then all requests are traced.
+// Filters will be invoked for each processed request, it is advised to make them
+// simple and fast.
+func WithFilter(f Filter) Option {
+	return optionFunc(func(c *config) {
+		c.Filters = append(c.Filters, f)
+	})
+}
+
+// WithPublicEndpoint treats every route as public: the span of the request
+// is the root of a new trace, which only links to the incoming span context.
+func WithPublicEndpoint() Option {
+	return optionFunc(func(c *config) {
+		c.PublicEndpoint = true
+	})
+}
+
+// WithPublicEndpointFn treats the requests fn returns true for as reaching a
+// public route, as WithPublicEndpoint does for all of them, which it
+// overrides.
+func WithPublicEndpointFn(fn func(*http.Request) bool) Option {
+	return optionFunc(func(c *config) {
+		c.PublicEndpointFn = fn
+	})
+}
+
+// WithSpanOptions configures an additional set of
+// trace.SpanOptions, which are applied to each new span.
+func WithSpanOptions(opts ...oteltrace.SpanStartOption) Option {
+	return optionFunc(func(c *config) {
+		c.SpanStartOptions = append(c.SpanStartOptions, opts...)
+	})
+}
+
+// WithPathParams records the route variables returned by mux.Vars on the
+// span, obfuscated. By default, no variable is recorded.
+func WithPathParams() Option {
+	return optionFunc(func(c *config) {
+		c.PathParams.Enabled = true
+	})
+}
+
+// WithPathParamsAllowList only records the route variables named names.
+func WithPathParamsAllowList(names ...string) Option {
+	return optionFunc(func(c *config) {
+		c.PathParams.Allow(names...)
+	})
+}
+
+// WithPathParamsDenyList never records the route variables named names,
+// even if they are allowed.
+func WithPathParamsDenyList(names ...string) Option {
+	return optionFunc(func(c *config) {
+		c.PathParams.Deny(names...)
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver // import "github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/gorilla/mux/otelmux/internal/httpserver"
+
+import (
+	"sort"
+
+	datautils "github.com/helios/go-sdk/data-utils"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RouteParamKeyPrefix is the prefix of the attributes recording path
+// parameters.
+const RouteParamKeyPrefix = "http.route.param."
+
+// PathParams selects the mux route variables recorded on spans. The zero
+// value records none.
+type PathParams struct {
+	Enabled bool
+	allow   map[string]struct{}
+	deny    map[string]struct{}
+}
+
+// Allow restricts the recorded variables to names, in addition to the
+// previously allowed ones.
+func (p *PathParams) Allow(names ...string) {
+	p.allow = addNames(p.allow, names)
+}
+
+// Deny excludes names from the recorded variables, even if allowed.
+func (p *PathParams) Deny(names ...string) {
+	p.deny = addNames(p.deny, names)
+}
+
+// Attributes returns the obfuscated values of the selected variables of
+// vars, ordered by name.
+func (p PathParams) Attributes(vars map[string]string) []attribute.KeyValue {
+	if !p.Enabled || len(vars) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		if p.selects(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	attrs := make([]attribute.KeyValue, 0, len(names))
+	for _, name := range names {
+		attrs = append(attrs, datautils.ObfuscateAttributeValue(attribute.String(RouteParamKeyPrefix+name, vars[name])))
+	}
+	return attrs
+}
+
+func (p PathParams) selects(name string) bool {
+	if _, ok := p.deny[name]; ok {
+		return false
+	}
+	_, ok := p.allow[name]
+	return ok || len(p.allow) == 0
+}
+
+func addNames(m map[string]struct{}, names []string) map[string]struct{} {
+	if m == nil {
+		m = make(map[string]struct{}, len(names))
+	}
+	for _, name := range names {
+		m[name] = struct{}{}
+	}
+	return m
+}
@@ -25,7 +25,10 @@ import (
 	"github.com/felixge/httpsnoop"
 	"github.com/gorilla/mux"
 	datautils "github.com/helios/go-sdk/data-utils"
+	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/gorilla/mux/otelmux/internal/httpserver"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
@@ -52,7 +55,7 @@ type bodyWrapper struct {
 
 func (w *bodyWrapper) Read(b []byte) (int, error) {
 	n, err := w.ReadCloser.Read(b)
-	if n > 0 && !w.metadataOnly{
+	if n > 0 && !w.metadataOnly {
 		shouldSkipContentByType, _ := datautils.ShouldSkipContentCollectionByContentType(w.contentType)
 		if !shouldSkipContentByType {
 			w.requestBody = append(w.requestBody, b[0:n]...)
@@ -86,9 +89,15 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 	if cfg.Propagators == nil {
 		cfg.Propagators = otel.GetTextMapPropagator()
 	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = global.MeterProvider()
+	}
 	if cfg.spanNameFormatter == nil {
 		cfg.spanNameFormatter = defaultSpanNameFunc
 	}
+	instruments := httpserver.NewInstruments(
+		cfg.MeterProvider.Meter(tracerName, metric.WithInstrumentationVersion(SemVersion())),
+	)
 	return func(handler http.Handler) http.Handler {
 		return traceware{
 			service:           service,
@@ -96,6 +105,12 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 			propagators:       cfg.Propagators,
 			handler:           handler,
 			spanNameFormatter: cfg.spanNameFormatter,
+			instruments:       instruments,
+			filters:           cfg.Filters,
+			publicEndpoint:    cfg.PublicEndpoint,
+			publicEndpointFn:  cfg.PublicEndpointFn,
+			spanStartOptions:  cfg.SpanStartOptions,
+			pathParams:        cfg.PathParams,
 		}
 	}
 }
@@ -113,12 +128,19 @@ type traceware struct {
 	propagators       propagation.TextMapPropagator
 	handler           http.Handler
 	spanNameFormatter func(string, *http.Request) string
+	instruments       *httpserver.Instruments
+	filters           []Filter
+	publicEndpoint    bool
+	publicEndpointFn  func(*http.Request) bool
+	spanStartOptions  []oteltrace.SpanStartOption
+	pathParams        httpserver.PathParams
 }
 
 type recordingResponseWriter struct {
 	writer       http.ResponseWriter
 	written      bool
 	status       int
+	size         int64
 	responseBody []byte
 	metadataOnly bool
 }
@@ -134,6 +156,7 @@ func getRRW(writer http.ResponseWriter, metadataOnly bool) *recordingResponseWri
 	rrw.metadataOnly = metadataOnly
 	rrw.written = false
 	rrw.status = http.StatusOK
+	rrw.size = 0
 	rrw.responseBody = []byte{}
 	rrw.writer = httpsnoop.Wrap(writer, httpsnoop.Hooks{
 		Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
@@ -148,7 +171,9 @@ func getRRW(writer http.ResponseWriter, metadataOnly bool) *recordingResponseWri
 						rrw.responseBody = append(rrw.responseBody, b...)
 					}
 				}
-				return next(b)
+				n, err := next(b)
+				rrw.size += int64(n)
+				return n, err
 			}
 		},
 		WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
@@ -175,6 +200,14 @@ func defaultSpanNameFunc(routeName string, _ *http.Request) string { return rout
 // ServeHTTP implements the http.Handler interface. It does the actual
 // tracing of the request.
 func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, f := range tw.filters {
+		if !f(r) {
+			// Simply pass through to the handler if a filter rejects the request
+			tw.handler.ServeHTTP(w, r)
+			return
+		}
+	}
+
 	ctx := tw.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 	routeStr := ""
 	route := mux.CurrentRoute(r)
@@ -188,6 +221,7 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+	metricAttrs := httpserver.RequestAttrs(tw.service, routeStr, r)
 	if routeStr == "" {
 		routeStr = fmt.Sprintf("HTTP %s route not found", r.Method)
 	}
@@ -196,8 +230,11 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		oteltrace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", r)...),
 		oteltrace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(r)...),
 		oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(tw.service, routeStr, r)...),
+		oteltrace.WithAttributes(tw.pathParams.Attributes(mux.Vars(r))...),
 		oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 	}
+	opts = append(opts, tw.spanStartOptions...)
+	opts = append(opts, httpserver.PublicEndpoint(ctx, r, tw.publicEndpoint, tw.publicEndpointFn)...)
 	spanName := tw.spanNameFormatter(routeStr, r)
 	metadataOnly := os.Getenv("HS_METADATA_ONLY") == "true"
 
@@ -208,11 +245,13 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		bw.metadataOnly = metadataOnly
 		r.Body = &bw
 	}
+	finished := tw.instruments.Started(ctx, metricAttrs)
 	ctx, span := tw.tracer.Start(ctx, spanName, opts...)
 	defer span.End()
 	r2 := r.WithContext(ctx)
 	rrw := getRRW(w, metadataOnly)
 	defer putRRW(rrw)
+	defer func() { finished(rrw.status, bw.read, rrw.size) }()
 
 	// Add traceresponse header
 	if span.IsRecording() {
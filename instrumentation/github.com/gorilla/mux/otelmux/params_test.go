@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/gorilla/mux/otelmux/internal/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPathParamsAttributes(t *testing.T) {
+	vars := map[string]string{"tenant": "acme", "order": "42", "token": "secret"}
+
+	assert.Empty(t, httpserver.PathParams{}.Attributes(vars))
+
+	var cfg config
+	WithPathParams().apply(&cfg)
+	WithPathParamsDenyList("token").apply(&cfg)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.route.param.order", "42"),
+		attribute.String("http.route.param.tenant", "acme"),
+	}, cfg.PathParams.Attributes(vars))
+
+	WithPathParamsAllowList("tenant", "token").apply(&cfg)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.route.param.tenant", "acme"),
+	}, cfg.PathParams.Attributes(vars))
+}
+
+func TestPathParams(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	router := mux.NewRouter()
+	router.Use(Middleware("foobar", WithTracerProvider(provider), WithPathParams()))
+	router.HandleFunc("/tenants/{tenant}/orders/{order}", func(w http.ResponseWriter, r *http.Request) {})
+
+	r := httptest.NewRequest("GET", "/tenants/acme/orders/42", nil)
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("http.route.param.tenant", "acme"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("http.route.param.order", "42"))
+}
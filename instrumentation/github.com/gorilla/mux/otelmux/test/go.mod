@@ -4,10 +4,11 @@ go 1.18
 
 require (
 	github.com/gorilla/mux v1.8.0
+	github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/gorilla/mux/otelmux v0.37.0
 	github.com/stretchr/testify v1.8.1
-	go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux v0.37.0
 	go.opentelemetry.io/otel v1.11.2
 	go.opentelemetry.io/otel/sdk v1.11.2
+	go.opentelemetry.io/otel/sdk/metric v0.34.0
 	go.opentelemetry.io/otel/trace v1.11.2
 )
 
@@ -19,9 +20,10 @@ require (
 	github.com/helios/go-sdk/data-utils v1.0.2 // indirect
 	github.com/ohler55/ojg v1.17.4 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/metric v0.34.0 // indirect
 	golang.org/x/exp v0.0.0-20230203172020-98cc5a0785f9 // indirect
 	golang.org/x/sys v0.1.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-replace go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux => ../
+replace github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/gorilla/mux/otelmux => ../
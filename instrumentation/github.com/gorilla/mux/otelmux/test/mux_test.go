@@ -15,6 +15,7 @@
 package test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -23,11 +24,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/gorilla/mux/otelmux"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -114,3 +119,88 @@ func assertSpan(t *testing.T, span sdktrace.ReadOnlySpan, name string, kind trac
 		assert.Equal(t, got[want.Key], want.Value)
 	}
 }
+
+func TestMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar", otelmux.WithMeterProvider(meterProvider)))
+	router.HandleFunc("/user/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	assertServerMetrics(t, reader, "/user/{id}", http.StatusOK, 2)
+}
+
+func TestMiddlewareOptions(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+		Remote:  true,
+	})
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar",
+		otelmux.WithTracerProvider(provider),
+		otelmux.WithPropagators(propagation.TraceContext{}),
+		otelmux.WithPublicEndpoint(),
+		otelmux.WithSpanNameFormatter(func(route string, r *http.Request) string { return r.Method + " " + route }),
+		otelmux.WithSpanOptions(trace.WithAttributes(attribute.String("edge", "true"))),
+		otelmux.WithFilter(func(r *http.Request) bool { return r.URL.Path != "/healthcheck" }),
+	))
+	router.HandleFunc("/user/{id}", ok)
+	router.HandleFunc("/healthcheck", ok)
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), remote)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	router.ServeHTTP(httptest.NewRecorder(), r)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthcheck", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /user/{id}", span.Name())
+	assert.Contains(t, span.Attributes(), attribute.String("edge", "true"))
+	assert.NotEqual(t, remote.TraceID(), span.SpanContext().TraceID())
+	require.Len(t, span.Links(), 1)
+	assert.Equal(t, remote, span.Links()[0].SpanContext)
+}
+
+// assertServerMetrics asserts that reader collected the metrics of a single
+// served request to route, answered with status and written response bytes.
+// An empty route asserts that requests are not identified by their route.
+func assertServerMetrics(t *testing.T, reader metric.Reader, route string, status int, written int64) {
+	t.Helper()
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	sums := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			require.Len(t, data.DataPoints, 1)
+			sums[m.Name] = data.DataPoints[0].Value
+		case metricdata.Histogram:
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, uint64(1), data.DataPoints[0].Count)
+			r, ok := data.DataPoints[0].Attributes.Value(semconv.HTTPRouteKey)
+			assert.Equal(t, route != "", ok)
+			assert.Equal(t, route, r.AsString())
+			s, _ := data.DataPoints[0].Attributes.Value(semconv.HTTPStatusCodeKey)
+			assert.Equal(t, int64(status), s.AsInt64())
+		}
+	}
+	assert.Equal(t, written, sums["http.server.response_content_length"])
+	assert.Equal(t, int64(0), sums["http.server.active_requests"])
+}
@@ -15,19 +15,40 @@
 package otelecho // import "github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/labstack/echo/otelecho"
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4/middleware"
 
+	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/labstack/echo/otelecho/internal/httpserver"
+
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
+// RouteParamKeyPrefix is the prefix of the attributes recording the path
+// parameters selected with WithPathParams. It is followed by the parameter
+// name.
+const RouteParamKeyPrefix = httpserver.RouteParamKeyPrefix
+
 // config is used to configure the mux middleware.
 type config struct {
-	TracerProvider oteltrace.TracerProvider
-	Propagators    propagation.TextMapPropagator
-	Skipper        middleware.Skipper
+	TracerProvider    oteltrace.TracerProvider
+	MeterProvider     metric.MeterProvider
+	Propagators       propagation.TextMapPropagator
+	Skipper           middleware.Skipper
+	Filters           []Filter
+	SpanNameFormatter func(string, *http.Request) string
+	PublicEndpoint    bool
+	PublicEndpointFn  func(*http.Request) bool
+	SpanStartOptions  []oteltrace.SpanStartOption
+	PathParams        httpserver.PathParams
 }
 
+// Filter is a predicate used to determine whether a given http.request should
+// be traced. A Filter must return true if the request should be traced.
+type Filter func(*http.Request) bool
+
 // Option specifies instrumentation configuration options.
 type Option interface {
 	apply(*config)
@@ -60,9 +81,90 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithSkipper specifies a skipper for allowing requests to skip generating spans.
 func WithSkipper(skipper middleware.Skipper) Option {
 	return optionFunc(func(cfg *config) {
 		cfg.Skipper = skipper
 	})
 }
+
+// WithSpanNameFormatter takes a function that will be called on every
+// request and the returned string will become the span name. The function is
+// given the default span name, the route template of the request.
+func WithSpanNameFormatter(f func(route string, r *http.Request) string) Option {
+	return optionFunc(func(c *config) {
+		c.SpanNameFormatter = f
+	})
+}
+
+// WithFilter adds a filter to the list of filters used by the middleware.
+// If any filter indicates to exclude a request then the request will not be
+// traced. All filters must allow a request to be traced for a Span to be created.
+// If no filters are provided then all requests are traced.
+// Filters will be invoked for each processed request, it is advised to make them
+// simple and fast.
+func WithFilter(f Filter) Option {
+	return optionFunc(func(c *config) {
+		c.Filters = append(c.Filters, f)
+	})
+}
+
+// WithPublicEndpoint makes the echo middleware start a new trace for every
+// request, linking the span to the incoming span context rather than using it
+// as the parent.
+func WithPublicEndpoint() Option {
+	return optionFunc(func(c *config) {
+		c.PublicEndpoint = true
+	})
+}
+
+// WithPublicEndpointFn makes the echo middleware start a new trace, linked to
+// the incoming one, for the requests fn returns true for. WithPublicEndpoint
+// overrides it.
+func WithPublicEndpointFn(fn func(*http.Request) bool) Option {
+	return optionFunc(func(c *config) {
+		c.PublicEndpointFn = fn
+	})
+}
+
+// WithSpanOptions configures an additional set of
+// trace.SpanOptions, which are applied to each new span.
+func WithSpanOptions(opts ...oteltrace.SpanStartOption) Option {
+	return optionFunc(func(c *config) {
+		c.SpanStartOptions = append(c.SpanStartOptions, opts...)
+	})
+}
+
+// WithPathParams records the path parameters of the matched echo route on
+// the span, obfuscated. By default, no parameter is recorded.
+func WithPathParams() Option {
+	return optionFunc(func(c *config) {
+		c.PathParams.Enabled = true
+	})
+}
+
+// WithPathParamsAllowList limits WithPathParams to the parameters named
+// names, and can be repeated to allow more.
+func WithPathParamsAllowList(names ...string) Option {
+	return optionFunc(func(c *config) {
+		c.PathParams.Allow(names...)
+	})
+}
+
+// WithPathParamsDenyList keeps the parameters named names off the span,
+// whether or not WithPathParamsAllowList allows them.
+func WithPathParamsDenyList(names ...string) Option {
+	return optionFunc(func(c *config) {
+		c.PathParams.Deny(names...)
+	})
+}
@@ -26,7 +26,10 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
+	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/labstack/echo/otelecho/internal/httpserver"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 
 	datautils "github.com/helios/go-sdk/data-utils"
 	"go.opentelemetry.io/otel/attribute"
@@ -151,6 +154,13 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 		cfg.Propagators = otel.GetTextMapPropagator()
 	}
 
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = global.MeterProvider()
+	}
+	instruments := httpserver.NewInstruments(
+		cfg.MeterProvider.Meter(tracerName, metric.WithInstrumentationVersion(SemVersion())),
+	)
+
 	if cfg.Skipper == nil {
 		cfg.Skipper = middleware.DefaultSkipper
 	}
@@ -160,6 +170,13 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 			if cfg.Skipper(c) {
 				return next(c)
 			}
+			for _, f := range cfg.Filters {
+				if !f(c.Request()) {
+					// Serve the request to the next middleware
+					// if a filter rejects the request.
+					return next(c)
+				}
+			}
 
 			c.Set(tracerKey, tracer)
 			request := c.Request()
@@ -175,12 +192,18 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 				oteltrace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", request)...),
 				oteltrace.WithAttributes(semconv.EndUserAttributesFromHTTPRequest(request)...),
 				oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(service, c.Path(), request)...),
+				oteltrace.WithAttributes(cfg.PathParams.Attributes(c.ParamNames(), c.ParamValues())...),
 				oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 			}
+			opts = append(opts, cfg.SpanStartOptions...)
+			opts = append(opts, httpserver.PublicEndpoint(ctx, request, cfg.PublicEndpoint, cfg.PublicEndpointFn)...)
 			spanName := c.Path()
 			if spanName == "" {
 				spanName = fmt.Sprintf("HTTP %s route not found", request.Method)
 			}
+			if cfg.SpanNameFormatter != nil {
+				spanName = cfg.SpanNameFormatter(spanName, request)
+			}
 			metadataOnly := os.Getenv("HS_METADATA_ONLY") == "true"
 			var bw bodyWrapper
 			if request.Body != nil && request.Body != http.NoBody {
@@ -189,11 +212,13 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 				request.Body = &bw
 			}
 
+			finished := instruments.Started(ctx, httpserver.RequestAttrs(service, c.Path(), request))
 			ctx, span := tracer.Start(ctx, spanName, opts...)
 			defer span.End()
 			rrw := getRRW(response)
 			rrw.metadataOnly = metadataOnly
 			defer putRRW(rrw)
+			defer func() { finished(c.Response().Status, bw.read, c.Response().Size) }()
 
 			// pass the span through the request context
 			c.SetRequest(request.WithContext(ctx))
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver // import "github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/labstack/echo/otelecho/internal/httpserver"
+
+import (
+	datautils "github.com/helios/go-sdk/data-utils"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RouteParamKeyPrefix is the prefix of the attributes recording path
+// parameters.
+const RouteParamKeyPrefix = "http.route.param."
+
+// PathParams selects the echo path parameters recorded on spans. The zero
+// value records none.
+type PathParams struct {
+	Enabled bool
+	allow   map[string]struct{}
+	deny    map[string]struct{}
+}
+
+// Allow restricts the recorded parameters to names, in addition to the
+// previously allowed ones.
+func (p *PathParams) Allow(names ...string) {
+	p.allow = addNames(p.allow, names)
+}
+
+// Deny excludes names from the recorded parameters, even if allowed.
+func (p *PathParams) Deny(names ...string) {
+	p.deny = addNames(p.deny, names)
+}
+
+// Attributes returns the obfuscated values of the selected parameters, in
+// the order of names. values holds the value of each name at the same index,
+// names without a value are skipped.
+func (p PathParams) Attributes(names, values []string) []attribute.KeyValue {
+	if !p.Enabled {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	for i, name := range names {
+		if i >= len(values) {
+			break
+		}
+		if p.selects(name) {
+			attrs = append(attrs, datautils.ObfuscateAttributeValue(attribute.String(RouteParamKeyPrefix+name, values[i])))
+		}
+	}
+	return attrs
+}
+
+func (p PathParams) selects(name string) bool {
+	if _, ok := p.deny[name]; ok {
+		return false
+	}
+	_, ok := p.allow[name]
+	return ok || len(p.allow) == 0
+}
+
+func addNames(m map[string]struct{}, names []string) map[string]struct{} {
+	if m == nil {
+		m = make(map[string]struct{}, len(names))
+	}
+	for _, name := range names {
+		m[name] = struct{}{}
+	}
+	return m
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelecho
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/labstack/echo/otelecho/internal/httpserver"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestPathParamsAttributes(t *testing.T) {
+	names := []string{"tenant", "order", "token"}
+	values := []string{"acme", "42", "secret"}
+
+	assert.Empty(t, httpserver.PathParams{}.Attributes(names, values))
+
+	var cfg config
+	WithPathParams().apply(&cfg)
+	WithPathParamsDenyList("token").apply(&cfg)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.route.param.tenant", "acme"),
+		attribute.String("http.route.param.order", "42"),
+	}, cfg.PathParams.Attributes(names, values))
+
+	WithPathParamsAllowList("tenant", "token").apply(&cfg)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.route.param.tenant", "acme"),
+	}, cfg.PathParams.Attributes(names, values))
+
+	// Values missing for some names are ignored.
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.route.param.tenant", "acme"),
+	}, cfg.PathParams.Attributes([]string{"tenant", "order"}, []string{"acme"}))
+}
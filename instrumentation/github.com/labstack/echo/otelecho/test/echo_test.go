@@ -17,6 +17,7 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -30,8 +31,12 @@ import (
 	"github.com/helios/opentelemetry-go-contrib/instrumentation/github.com/labstack/echo/otelecho"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 
 	"go.opentelemetry.io/otel/attribute"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -138,3 +143,105 @@ func TestError(t *testing.T) {
 	// server errors set the status
 	assert.Equal(t, codes.Error, span.Status().Code)
 }
+
+func TestMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar", otelecho.WithMeterProvider(meterProvider)))
+	router.GET("/user/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	assertServerMetrics(t, reader, "/user/:id", http.StatusOK, 2)
+}
+
+func TestMiddlewareOptions(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+	remote := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: oteltrace.TraceID{0x01},
+		SpanID:  oteltrace.SpanID{0x01},
+		Remote:  true,
+	})
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar",
+		otelecho.WithTracerProvider(provider),
+		otelecho.WithPropagators(propagation.TraceContext{}),
+		otelecho.WithPublicEndpoint(),
+		otelecho.WithSpanNameFormatter(func(route string, r *http.Request) string { return r.Method + " " + route }),
+		otelecho.WithSpanOptions(oteltrace.WithAttributes(attribute.String("edge", "true"))),
+		otelecho.WithFilter(func(r *http.Request) bool { return r.URL.Path != "/healthcheck" }),
+	))
+	router.GET("/user/:id", func(c echo.Context) error { return nil })
+	router.GET("/healthcheck", func(c echo.Context) error { return nil })
+
+	r := httptest.NewRequest("GET", "/user/123", nil)
+	ctx := oteltrace.ContextWithRemoteSpanContext(context.Background(), remote)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	router.ServeHTTP(httptest.NewRecorder(), r)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/healthcheck", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /user/:id", span.Name())
+	assert.Contains(t, span.Attributes(), attribute.String("edge", "true"))
+	assert.NotEqual(t, remote.TraceID(), span.SpanContext().TraceID())
+	require.Len(t, span.Links(), 1)
+	assert.Equal(t, remote, span.Links()[0].SpanContext)
+}
+
+func TestPathParams(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar", otelecho.WithTracerProvider(provider), otelecho.WithPathParams()))
+	router.GET("/tenants/:tenant/orders/:order", func(c echo.Context) error { return nil })
+
+	r := httptest.NewRequest("GET", "/tenants/acme/orders/42", nil)
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("http.route.param.tenant", "acme"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("http.route.param.order", "42"))
+}
+
+// assertServerMetrics asserts that reader collected the metrics of a single
+// served request to route, answered with status and written response bytes.
+// An empty route asserts that requests are not identified by their route.
+func assertServerMetrics(t *testing.T, reader metric.Reader, route string, status int, written int64) {
+	t.Helper()
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	sums := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			require.Len(t, data.DataPoints, 1)
+			sums[m.Name] = data.DataPoints[0].Value
+		case metricdata.Histogram:
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, uint64(1), data.DataPoints[0].Count)
+			r, ok := data.DataPoints[0].Attributes.Value(semconv.HTTPRouteKey)
+			assert.Equal(t, route != "", ok)
+			assert.Equal(t, route, r.AsString())
+			s, _ := data.DataPoints[0].Attributes.Value(semconv.HTTPStatusCodeKey)
+			assert.Equal(t, int64(status), s.AsInt64())
+		}
+	}
+	assert.Equal(t, written, sums["http.server.response_content_length"])
+	assert.Equal(t, int64(0), sums["http.server.active_requests"])
+}
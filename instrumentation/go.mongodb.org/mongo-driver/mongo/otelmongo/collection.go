@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"context"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection is a mongo.Collection setting the trace context comment returned
+// by Comment on the operations that support comments, so that database-side
+// profiling can be correlated with traces. Comments set by users in the
+// operation options are never overwritten.
+type Collection struct {
+	*mongo.Collection
+
+	cfg config
+}
+
+// WrapCollection returns coll wrapped to set the trace context of every
+// operation as its comment. The trace context is formatted with the
+// propagators set with WithPropagators.
+func WrapCollection(coll *mongo.Collection, opts ...Option) *Collection {
+	return &Collection{Collection: coll, cfg: newConfig(opts...)}
+}
+
+// comment returns the trace context comment of an operation, unless ctx holds
+// no valid span context or one of the operation options, a slice of pointers
+// to options structs, already sets a comment.
+func (c *Collection) comment(ctx context.Context, opts interface{}) (string, bool) {
+	v := reflect.ValueOf(opts)
+	for i := 0; i < v.Len(); i++ {
+		o := v.Index(i)
+		if o.IsNil() {
+			continue
+		}
+		if f := o.Elem().FieldByName("Comment"); f.IsValid() && !f.IsZero() {
+			return "", false
+		}
+	}
+
+	comment := c.cfg.comment(ctx)
+	return comment, comment != ""
+}
+
+// BulkWrite calls BulkWrite of the wrapped collection with the trace context
+// comment.
+func (c *Collection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.BulkWrite().SetComment(comment))
+	}
+	return c.Collection.BulkWrite(ctx, models, opts...)
+}
+
+// InsertOne calls InsertOne of the wrapped collection with the trace context
+// comment.
+func (c *Collection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.InsertOne().SetComment(comment))
+	}
+	return c.Collection.InsertOne(ctx, document, opts...)
+}
+
+// InsertMany calls InsertMany of the wrapped collection with the trace
+// context comment.
+func (c *Collection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.InsertMany().SetComment(comment))
+	}
+	return c.Collection.InsertMany(ctx, documents, opts...)
+}
+
+// DeleteOne calls DeleteOne of the wrapped collection with the trace context
+// comment.
+func (c *Collection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Delete().SetComment(comment))
+	}
+	return c.Collection.DeleteOne(ctx, filter, opts...)
+}
+
+// DeleteMany calls DeleteMany of the wrapped collection with the trace
+// context comment.
+func (c *Collection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Delete().SetComment(comment))
+	}
+	return c.Collection.DeleteMany(ctx, filter, opts...)
+}
+
+// UpdateByID calls UpdateByID of the wrapped collection with the trace
+// context comment.
+func (c *Collection) UpdateByID(ctx context.Context, id interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Update().SetComment(comment))
+	}
+	return c.Collection.UpdateByID(ctx, id, update, opts...)
+}
+
+// UpdateOne calls UpdateOne of the wrapped collection with the trace context
+// comment.
+func (c *Collection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Update().SetComment(comment))
+	}
+	return c.Collection.UpdateOne(ctx, filter, update, opts...)
+}
+
+// UpdateMany calls UpdateMany of the wrapped collection with the trace
+// context comment.
+func (c *Collection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Update().SetComment(comment))
+	}
+	return c.Collection.UpdateMany(ctx, filter, update, opts...)
+}
+
+// ReplaceOne calls ReplaceOne of the wrapped collection with the trace
+// context comment.
+func (c *Collection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Replace().SetComment(comment))
+	}
+	return c.Collection.ReplaceOne(ctx, filter, replacement, opts...)
+}
+
+// Aggregate calls Aggregate of the wrapped collection with the trace context
+// comment.
+func (c *Collection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Aggregate().SetComment(comment))
+	}
+	return c.Collection.Aggregate(ctx, pipeline, opts...)
+}
+
+// CountDocuments calls CountDocuments of the wrapped collection with the
+// trace context comment.
+func (c *Collection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Count().SetComment(comment))
+	}
+	return c.Collection.CountDocuments(ctx, filter, opts...)
+}
+
+// EstimatedDocumentCount calls EstimatedDocumentCount of the wrapped
+// collection with the trace context comment.
+func (c *Collection) EstimatedDocumentCount(ctx context.Context, opts ...*options.EstimatedDocumentCountOptions) (int64, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.EstimatedDocumentCount().SetComment(comment))
+	}
+	return c.Collection.EstimatedDocumentCount(ctx, opts...)
+}
+
+// Distinct calls Distinct of the wrapped collection with the trace context
+// comment.
+func (c *Collection) Distinct(ctx context.Context, fieldName string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Distinct().SetComment(comment))
+	}
+	return c.Collection.Distinct(ctx, fieldName, filter, opts...)
+}
+
+// Find calls Find of the wrapped collection with the trace context comment.
+func (c *Collection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.Find().SetComment(comment))
+	}
+	return c.Collection.Find(ctx, filter, opts...)
+}
+
+// FindOne calls FindOne of the wrapped collection with the trace context
+// comment.
+func (c *Collection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.FindOne().SetComment(comment))
+	}
+	return c.Collection.FindOne(ctx, filter, opts...)
+}
+
+// FindOneAndDelete calls FindOneAndDelete of the wrapped collection with the
+// trace context comment.
+func (c *Collection) FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) *mongo.SingleResult {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.FindOneAndDelete().SetComment(comment))
+	}
+	return c.Collection.FindOneAndDelete(ctx, filter, opts...)
+}
+
+// FindOneAndReplace calls FindOneAndReplace of the wrapped collection with
+// the trace context comment.
+func (c *Collection) FindOneAndReplace(ctx context.Context, filter interface{}, replacement interface{}, opts ...*options.FindOneAndReplaceOptions) *mongo.SingleResult {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.FindOneAndReplace().SetComment(comment))
+	}
+	return c.Collection.FindOneAndReplace(ctx, filter, replacement, opts...)
+}
+
+// FindOneAndUpdate calls FindOneAndUpdate of the wrapped collection with the
+// trace context comment.
+func (c *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.FindOneAndUpdate().SetComment(comment))
+	}
+	return c.Collection.FindOneAndUpdate(ctx, filter, update, opts...)
+}
+
+// Watch calls Watch of the wrapped collection with the trace context comment.
+func (c *Collection) Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	if comment, ok := c.comment(ctx, opts); ok {
+		opts = append(opts, options.ChangeStream().SetComment(comment))
+	}
+	return c.Collection.Watch(ctx, pipeline, opts...)
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Comment returns the trace context of ctx formatted as a sqlcommenter
+// comment, e.g. traceparent='00-...-01'. The result is meant to be set as the
+// comment of an operation, where it shows up in the slow query log and in
+// currentOp, letting database-side profiling be correlated with traces. An
+// empty string is returned when ctx holds no valid span context.
+//
+// The comment has to be set when the operation options are built: command
+// monitors only observe commands after they are encoded. Collections wrapped
+// with WrapCollection set it automatically.
+func Comment(ctx context.Context, opts ...Option) string {
+	return newConfig(opts...).comment(ctx)
+}
+
+func (cfg config) comment(ctx context.Context) string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ""
+	}
+
+	carrier := propagation.MapCarrier{}
+	cfg.Propagators.Inject(ctx, carrier)
+
+	keys := carrier.Keys()
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s='%s'", url.QueryEscape(k), url.QueryEscape(carrier.Get(k))))
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestComment(t *testing.T) {
+	propagators := WithPropagators(propagation.TraceContext{})
+	assert.Empty(t, Comment(context.Background(), propagators))
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	assert.Equal(t, "traceparent='00-01000000000000000000000000000000-0200000000000000-01'", Comment(ctx, propagators))
+}
+
+func TestCollectionComment(t *testing.T) {
+	c := WrapCollection(nil, WithPropagators(propagation.TraceContext{}))
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	want := "traceparent='00-01000000000000000000000000000000-0200000000000000-01'"
+
+	comment, ok := c.comment(ctx, []*options.FindOptions{nil, options.Find().SetLimit(1)})
+	require.True(t, ok)
+	assert.Equal(t, want, comment)
+
+	comment, ok = c.comment(ctx, []*options.UpdateOptions(nil))
+	require.True(t, ok)
+	assert.Equal(t, want, comment)
+
+	// Comments set by users are kept, whatever their type.
+	_, ok = c.comment(ctx, []*options.FindOptions{options.Find().SetComment("report:daily")})
+	assert.False(t, ok)
+	_, ok = c.comment(ctx, []*options.UpdateOptions{options.Update().SetComment(bson.D{{Key: "job", Value: "daily"}})})
+	assert.False(t, ok)
+
+	_, ok = c.comment(context.Background(), []*options.FindOptions{})
+	assert.False(t, ok)
+}
@@ -16,6 +16,9 @@ package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mong
 
 import (
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -24,16 +27,29 @@ const defaultTracerName = "go.opentelemetry.io/contrib/instrumentation/go.mongod
 // config is used to configure the mongo tracer.
 type config struct {
 	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagators    propagation.TextMapPropagator
 
 	Tracer trace.Tracer
 
 	CommandAttributeDisabled bool
+
+	CommandMaxLength int
+
+	RawCommandCollections map[string]struct{}
+
+	CaptureReply bool
+
+	CursorCorrelation bool
 }
 
 // newConfig returns a config with all Options set.
 func newConfig(opts ...Option) config {
 	cfg := config{
-		TracerProvider: otel.GetTracerProvider(),
+		TracerProvider:   otel.GetTracerProvider(),
+		MeterProvider:    global.MeterProvider(),
+		Propagators:      otel.GetTextMapPropagator(),
+		CommandMaxLength: DefaultCommandMaxLength,
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
@@ -67,6 +83,27 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
+// WithPropagators specifies propagators to use for injecting the trace
+// context into command comments. If none are specified, global ones will be
+// used.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return optionFunc(func(cfg *config) {
+		if propagators != nil {
+			cfg.Propagators = propagators
+		}
+	})
+}
+
 // WithCommandAttributeDisabled specifies if the MongoDB command is added as an attribute to Spans or not.
 // The MongoDB command will be added as an attribute to Spans by default if this option is not provided.
 func WithCommandAttributeDisabled(disabled bool) Option {
@@ -74,3 +111,48 @@ func WithCommandAttributeDisabled(disabled bool) Option {
 		cfg.CommandAttributeDisabled = disabled
 	})
 }
+
+// WithCommandMaxLength specifies the maximum length, in bytes, of the MongoDB
+// command added as an attribute to Spans. Longer commands are truncated. A
+// length of zero or less disables truncation. If this option is not provided,
+// DefaultCommandMaxLength is used.
+func WithCommandMaxLength(length int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CommandMaxLength = length
+	})
+}
+
+// WithRawCommandCollections specifies collections for which the MongoDB
+// command is added to Spans with its values instead of being sanitized. The
+// raw command goes through the data obfuscation rules before being recorded,
+// and is sanitized anyway when HS_METADATA_ONLY is set.
+func WithRawCommandCollections(collections ...string) Option {
+	return optionFunc(func(cfg *config) {
+		if cfg.RawCommandCollections == nil {
+			cfg.RawCommandCollections = make(map[string]struct{}, len(collections))
+		}
+		for _, c := range collections {
+			cfg.RawCommandCollections[c] = struct{}{}
+		}
+	})
+}
+
+// WithReplyCapture specifies that command replies are described on Spans: the
+// number of documents matched and modified, the cursor id, the number of
+// documents returned in a cursor batch and an obfuscated sample of the reply,
+// truncated like the MongoDB command but never longer than 4096 bytes. The
+// sample is left out when HS_METADATA_ONLY is set.
+func WithReplyCapture() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CaptureReply = true
+	})
+}
+
+// WithCursorCorrelation specifies that getMore Spans are linked to the Span of
+// the find or aggregate command that opened their cursor, so that paged reads
+// can be stitched together.
+func WithCursorCorrelation() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CursorCorrelation = true
+	})
+}
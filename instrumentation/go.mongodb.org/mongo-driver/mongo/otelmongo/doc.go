@@ -18,7 +18,10 @@
 // go.mongodb.org/mongo-driver/mongo.
 //
 // `NewMonitor` will return an event.CommandMonitor which is used to trace
-// requests.
+// requests. `NewPoolMonitor` will return an event.PoolMonitor which is used
+// to record connection pool metrics. `WrapCollection` sets the trace context
+// as the comment of collection operations, correlating database-side
+// profiling with traces, and `Comment` formats it for other operations.
 //
 // This code was originally based on the following:
 // - https://github.com/DataDog/dd-trace-go/tree/02f0449efa3cb382d499fadc873957385dcb2192/contrib/go.mongodb.org/mongo-driver/mongo
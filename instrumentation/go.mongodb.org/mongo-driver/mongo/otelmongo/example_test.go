@@ -28,6 +28,7 @@ func Example() {
 	// connect to MongoDB
 	opts := options.Client()
 	opts.Monitor = otelmongo.NewMonitor()
+	opts.PoolMonitor = otelmongo.NewPoolMonitor()
 	opts.ApplyURI("mongodb://localhost:27017")
 	client, err := mongo.Connect(context.Background(), opts)
 	if err != nil {
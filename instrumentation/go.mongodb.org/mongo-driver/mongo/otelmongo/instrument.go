@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// MongoDB metrics.
+const (
+	CommandDuration    = "db.client.operation.duration"       // Duration of commands, milliseconds
+	ConnectionUsage    = "db.client.connections.usage"        // Number of connections by state
+	ConnectionsCreated = "db.client.connections.created"      // Number of connections created
+	ConnectionsClosed  = "db.client.connections.closed"       // Number of connections closed
+	ConnectionWaitTime = "db.client.connections.wait_time"    // Approximate time to check out a connection, milliseconds
+	PoolCleared        = "db.client.connections.pool.cleared" // Number of times a pool was cleared
+)
+
+// Connection pool attributes.
+const (
+	PoolNameKey = attribute.Key("pool.name")
+	StateKey    = attribute.Key("state")
+	ReasonKey   = attribute.Key("reason")
+)
+
+// Connection states reported by the ConnectionUsage metric.
+var (
+	StateUsed = StateKey.String("used")
+	StateIdle = StateKey.String("idle")
+)
+
+type instruments struct {
+	// commandDuration is the time taken by commands.
+	commandDuration syncfloat64.Histogram
+
+	// connectionUsage is the number of used and idle connections.
+	connectionUsage syncint64.UpDownCounter
+
+	// connectionsCreated is the number of connections created.
+	connectionsCreated syncint64.Counter
+
+	// connectionsClosed is the number of connections closed.
+	connectionsClosed syncint64.Counter
+
+	// waitTime is the approximate time taken to check out a connection.
+	waitTime syncfloat64.Histogram
+
+	// poolCleared is the number of times a pool was cleared.
+	poolCleared syncint64.Counter
+}
+
+// newInstruments will create instruments using a meter
+// from the given provider p.
+func newInstruments(p metric.MeterProvider) *instruments {
+	meter := p.Meter(
+		defaultTracerName,
+		metric.WithInstrumentationVersion(SemVersion()),
+	)
+	instruments := &instruments{}
+	var err error
+
+	if instruments.commandDuration, err = meter.SyncFloat64().Histogram(
+		CommandDuration,
+		instrument.WithDescription("Duration of MongoDB commands"),
+		instrument.WithUnit(unit.Milliseconds),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.connectionUsage, err = meter.SyncInt64().UpDownCounter(
+		ConnectionUsage,
+		instrument.WithDescription("Number of connections that are currently in the state described by the state attribute"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.connectionsCreated, err = meter.SyncInt64().Counter(
+		ConnectionsCreated,
+		instrument.WithDescription("Number of connections created"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.connectionsClosed, err = meter.SyncInt64().Counter(
+		ConnectionsClosed,
+		instrument.WithDescription("Number of connections closed"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.waitTime, err = meter.SyncFloat64().Histogram(
+		ConnectionWaitTime,
+		instrument.WithDescription("Approximate time taken to check out a connection from the pool"),
+		instrument.WithUnit(unit.Milliseconds),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.poolCleared, err = meter.SyncInt64().Counter(
+		PoolCleared,
+		instrument.WithDescription("Number of times a connection pool was cleared"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return instruments
+}
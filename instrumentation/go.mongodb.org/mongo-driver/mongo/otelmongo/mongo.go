@@ -15,11 +15,13 @@
 package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 
 import (
+	"container/list"
 	"context"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -36,10 +38,26 @@ type spanKey struct {
 	RequestID    int64
 }
 
+// cursorKey identifies a cursor. Cursor ids are only unique per server.
+type cursorKey struct {
+	Address string
+	ID      int64
+}
+
+type command struct {
+	span  trace.Span
+	attrs []attribute.KeyValue
+	// cursorID is the cursor continued by a getMore command.
+	cursorID int64
+}
+
 type monitor struct {
 	sync.Mutex
-	spans map[spanKey]trace.Span
-	cfg   config
+	spans       map[spanKey]command
+	cursors     map[cursorKey]*list.Element
+	cursorOrder *list.List // of trackedCursor, oldest first
+	cfg         config
+	instruments *instruments
 }
 
 func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
@@ -55,43 +73,66 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 		semconv.NetPeerPortKey.Int(port),
 		semconv.NetTransportTCP,
 	}
-	if !m.cfg.CommandAttributeDisabled {
-		attrs = append(attrs, semconv.DBStatementKey.String(sanitizeCommand(evt.Command)))
+	metricAttrs := []attribute.KeyValue{
+		semconv.DBSystemMongoDB,
+		semconv.DBOperationKey.String(evt.CommandName),
 	}
-	if collection, err := extractCollection(evt); err == nil && collection != "" {
+	collection, err := extractCollection(evt)
+	if err == nil && collection != "" {
 		spanName = collection + "."
 		attrs = append(attrs, semconv.DBMongoDBCollectionKey.String(collection))
+		metricAttrs = append(metricAttrs, semconv.DBMongoDBCollectionKey.String(collection))
+	}
+	if !m.cfg.CommandAttributeDisabled {
+		attrs = append(attrs, m.cfg.statement(evt.Command, collection))
 	}
 	spanName += evt.CommandName
 	opts := []trace.SpanStartOption{
 		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(attrs...),
 	}
+
+	var cursorID int64
+	if m.cfg.CursorCorrelation {
+		switch evt.CommandName {
+		case "getMore":
+			if id, ok := lookupInt64(evt.Command, "getMore"); ok {
+				cursorID = id
+				attrs = append(attrs, CursorIDKey.Int64(id))
+				if link, ok := m.cursorLink(serverAddress(evt.ConnectionID), id); ok {
+					opts = append(opts, trace.WithLinks(link))
+				}
+			}
+		case "killCursors":
+			m.forgetCursors(serverAddress(evt.ConnectionID), evt.Command)
+		}
+	}
+
+	opts = append(opts, trace.WithAttributes(attrs...))
 	_, span := m.cfg.Tracer.Start(ctx, spanName, opts...)
 	key := spanKey{
 		ConnectionID: evt.ConnectionID,
 		RequestID:    evt.RequestID,
 	}
 	m.Lock()
-	m.spans[key] = span
+	m.spans[key] = command{span: span, attrs: metricAttrs, cursorID: cursorID}
 	m.Unlock()
 }
 
 func (m *monitor) Succeeded(ctx context.Context, evt *event.CommandSucceededEvent) {
-	m.Finished(&evt.CommandFinishedEvent, nil)
+	m.Finished(ctx, &evt.CommandFinishedEvent, evt.Reply, nil)
 }
 
 func (m *monitor) Failed(ctx context.Context, evt *event.CommandFailedEvent) {
-	m.Finished(&evt.CommandFinishedEvent, fmt.Errorf("%s", evt.Failure))
+	m.Finished(ctx, &evt.CommandFinishedEvent, nil, fmt.Errorf("%s", evt.Failure))
 }
 
-func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
+func (m *monitor) Finished(ctx context.Context, evt *event.CommandFinishedEvent, reply bson.Raw, err error) {
 	key := spanKey{
 		ConnectionID: evt.ConnectionID,
 		RequestID:    evt.RequestID,
 	}
 	m.Lock()
-	span, ok := m.spans[key]
+	cmd, ok := m.spans[key]
 	if ok {
 		delete(m.spans, key)
 	}
@@ -100,18 +141,23 @@ func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
 		return
 	}
 
-	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+	// Use floating point division here for higher precision (instead of Millisecond method).
+	m.instruments.commandDuration.Record(ctx, float64(evt.DurationNanos)/float64(time.Millisecond), cmd.attrs...)
+
+	if reply != nil {
+		if m.cfg.CaptureReply {
+			cmd.span.SetAttributes(m.cfg.replyAttributes(reply)...)
+		}
+		if m.cfg.CursorCorrelation {
+			m.trackCursor(serverAddress(evt.ConnectionID), cmd, reply)
+		}
 	}
 
-	span.End()
-}
+	if err != nil {
+		cmd.span.SetStatus(codes.Error, err.Error())
+	}
 
-// TODO sanitize values where possible
-// TODO limit maximum size.
-func sanitizeCommand(command bson.Raw) string {
-	b, _ := bson.MarshalExtJSON(command, false, false)
-	return string(b)
+	cmd.span.End()
 }
 
 // extractCollection extracts the collection for the given mongodb command event.
@@ -133,13 +179,10 @@ func extractCollection(evt *event.CommandStartedEvent) (string, error) {
 	return "", fmt.Errorf("collection name not found")
 }
 
-// NewMonitor creates a new mongodb event CommandMonitor.
+// NewMonitor creates a new mongodb event CommandMonitor. The duration of
+// commands is recorded with the MeterProvider set with WithMeterProvider.
 func NewMonitor(opts ...Option) *event.CommandMonitor {
-	cfg := newConfig(opts...)
-	m := &monitor{
-		spans: make(map[spanKey]trace.Span),
-		cfg:   cfg,
-	}
+	m := newMonitor(newConfig(opts...))
 	return &event.CommandMonitor{
 		Started:   m.Started,
 		Succeeded: m.Succeeded,
@@ -147,6 +190,25 @@ func NewMonitor(opts ...Option) *event.CommandMonitor {
 	}
 }
 
+func newMonitor(cfg config) *monitor {
+	return &monitor{
+		spans:       make(map[spanKey]command),
+		cursors:     make(map[cursorKey]*list.Element),
+		cursorOrder: list.New(),
+		cfg:         cfg,
+		instruments: newInstruments(cfg.MeterProvider),
+	}
+}
+
+// serverAddress returns the address of the server a connection, identified as
+// "host:port[id]", is open to.
+func serverAddress(connectionID string) string {
+	if idx := strings.IndexByte(connectionID, '['); idx >= 0 {
+		return connectionID[:idx]
+	}
+	return connectionID
+}
+
 func peerInfo(evt *event.CommandStartedEvent) (hostname string, port int) {
 	hostname = evt.ConnectionID
 	port = 27017
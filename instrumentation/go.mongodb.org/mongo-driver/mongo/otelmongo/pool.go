@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+type connKey struct {
+	Address      string
+	ConnectionID uint64
+}
+
+type connState int
+
+const (
+	connPending connState = iota // Created, not ready to be used yet.
+	connIdle
+	connUsed
+)
+
+type poolMonitor struct {
+	sync.Mutex
+	instruments *instruments
+	conns       map[connKey]connState
+	// checkouts holds the start time of pending checkouts by address. Pool
+	// events of driver 1.11 do not identify checkouts, so they are paired in
+	// the order they started. Checkouts served out of order under concurrency
+	// are attributed the wait time of another one.
+	checkouts map[string][]time.Time
+}
+
+// NewPoolMonitor creates a new mongodb event PoolMonitor recording the number
+// of used and idle connections, the number of connections created and closed,
+// the time taken to check out a connection and the number of times a pool was
+// cleared. Metrics are recorded with the MeterProvider set with
+// WithMeterProvider.
+//
+// The checkout wait time is approximate: pool events do not identify
+// checkouts, so concurrent checkouts completing out of order have their wait
+// times swapped.
+func NewPoolMonitor(opts ...Option) *event.PoolMonitor {
+	cfg := newConfig(opts...)
+	m := &poolMonitor{
+		instruments: newInstruments(cfg.MeterProvider),
+		conns:       make(map[connKey]connState),
+		checkouts:   make(map[string][]time.Time),
+	}
+	return &event.PoolMonitor{
+		Event: m.Event,
+	}
+}
+
+func (m *poolMonitor) Event(evt *event.PoolEvent) {
+	ctx := context.Background()
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemMongoDB,
+		PoolNameKey.String(evt.Address),
+	}
+	key := connKey{Address: evt.Address, ConnectionID: evt.ConnectionID}
+
+	m.Lock()
+	defer m.Unlock()
+
+	switch evt.Type {
+	case event.ConnectionCreated:
+		m.instruments.connectionsCreated.Add(ctx, 1, attrs...)
+		m.setState(ctx, key, connPending, attrs)
+	case event.ConnectionReady, event.ConnectionReturned:
+		m.setState(ctx, key, connIdle, attrs)
+	case event.ConnectionClosed:
+		m.instruments.connectionsClosed.Add(ctx, 1, append(attrs, ReasonKey.String(evt.Reason))...)
+		m.release(ctx, key, attrs)
+	case event.GetStarted:
+		m.checkouts[evt.Address] = append(m.checkouts[evt.Address], time.Now())
+	case event.GetSucceeded:
+		m.recordWait(ctx, evt.Address, attrs)
+		m.setState(ctx, key, connUsed, attrs)
+	case event.GetFailed:
+		m.recordWait(ctx, evt.Address, append(attrs, ReasonKey.String(evt.Reason)))
+	case event.PoolCleared:
+		m.instruments.poolCleared.Add(ctx, 1, attrs...)
+	case event.PoolClosedEvent:
+		for k := range m.conns {
+			if k.Address == evt.Address {
+				m.release(ctx, k, attrs)
+			}
+		}
+		delete(m.checkouts, evt.Address)
+	}
+}
+
+// setState moves the connection identified by key to state s, updating the
+// number of connections in each state.
+func (m *poolMonitor) setState(ctx context.Context, key connKey, s connState, attrs []attribute.KeyValue) {
+	old, ok := m.conns[key]
+	m.conns[key] = s
+	if ok && old == s {
+		return
+	}
+	if ok {
+		m.addUsage(ctx, old, -1, attrs)
+	}
+	m.addUsage(ctx, s, 1, attrs)
+}
+
+// release stops tracking the connection identified by key.
+func (m *poolMonitor) release(ctx context.Context, key connKey, attrs []attribute.KeyValue) {
+	if old, ok := m.conns[key]; ok {
+		m.addUsage(ctx, old, -1, attrs)
+		delete(m.conns, key)
+	}
+}
+
+func (m *poolMonitor) addUsage(ctx context.Context, s connState, incr int64, attrs []attribute.KeyValue) {
+	switch s {
+	case connIdle:
+		m.instruments.connectionUsage.Add(ctx, incr, append(attrs, StateIdle)...)
+	case connUsed:
+		m.instruments.connectionUsage.Add(ctx, incr, append(attrs, StateUsed)...)
+	}
+}
+
+// recordWait records the time taken by the oldest pending checkout for address.
+func (m *poolMonitor) recordWait(ctx context.Context, address string, attrs []attribute.KeyValue) {
+	pending := m.checkouts[address]
+	if len(pending) == 0 {
+		return
+	}
+	start := pending[0]
+	if len(pending) == 1 {
+		delete(m.checkouts, address)
+	} else {
+		m.checkouts[address] = pending[1:]
+	}
+	// Use floating point division here for higher precision (instead of Millisecond method).
+	m.instruments.waitTime.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), attrs...)
+}
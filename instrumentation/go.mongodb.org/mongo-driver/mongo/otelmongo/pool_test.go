@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestPoolMonitorConnectionStates(t *testing.T) {
+	const address = "localhost:27017"
+	m := &poolMonitor{
+		instruments: newInstruments(metric.NewNoopMeterProvider()),
+		conns:       make(map[connKey]connState),
+		checkouts:   make(map[string][]time.Time),
+	}
+	key := connKey{Address: address, ConnectionID: 1}
+
+	m.Event(&event.PoolEvent{Type: event.GetStarted, Address: address})
+	m.Event(&event.PoolEvent{Type: event.GetStarted, Address: address})
+	assert.Len(t, m.checkouts[address], 2)
+
+	m.Event(&event.PoolEvent{Type: event.ConnectionCreated, Address: address, ConnectionID: 1})
+	assert.Equal(t, connPending, m.conns[key])
+
+	m.Event(&event.PoolEvent{Type: event.ConnectionReady, Address: address, ConnectionID: 1})
+	assert.Equal(t, connIdle, m.conns[key])
+
+	m.Event(&event.PoolEvent{Type: event.GetSucceeded, Address: address, ConnectionID: 1})
+	assert.Equal(t, connUsed, m.conns[key])
+	assert.Len(t, m.checkouts[address], 1)
+
+	m.Event(&event.PoolEvent{Type: event.GetFailed, Address: address, Reason: event.ReasonTimedOut})
+	assert.NotContains(t, m.checkouts, address)
+
+	m.Event(&event.PoolEvent{Type: event.ConnectionReturned, Address: address, ConnectionID: 1})
+	assert.Equal(t, connIdle, m.conns[key])
+
+	m.Event(&event.PoolEvent{Type: event.ConnectionClosed, Address: address, ConnectionID: 1, Reason: event.ReasonIdle})
+	assert.NotContains(t, m.conns, key)
+
+	m.Event(&event.PoolEvent{Type: event.ConnectionCreated, Address: address, ConnectionID: 2})
+	m.Event(&event.PoolEvent{Type: event.PoolClosedEvent, Address: address})
+	assert.Empty(t, m.conns)
+}
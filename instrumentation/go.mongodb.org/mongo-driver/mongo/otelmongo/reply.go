@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"os"
+
+	datautils "github.com/helios/go-sdk/data-utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Reply attributes.
+const (
+	ReplyNKey         = attribute.Key("db.mongodb.reply.n")
+	ReplyNModifiedKey = attribute.Key("db.mongodb.reply.n_modified")
+	ReplyBatchSizeKey = attribute.Key("db.mongodb.reply.batch_size")
+	ReplySampleKey    = attribute.Key("db.mongodb.reply")
+	CursorIDKey       = attribute.Key("db.mongodb.cursor.id")
+)
+
+const (
+	// maxTrackedCursors is the maximum number of open cursors remembered for
+	// correlation.
+	maxTrackedCursors = 1024
+
+	// maxReplySampleLength is the maximum length, in bytes, of the reply
+	// sample, whatever the command maximum length.
+	maxReplySampleLength = 4096
+)
+
+// replyAttributes returns the attributes describing a command reply. The
+// sample of the reply is obfuscated and truncated to the command maximum
+// length, at most maxReplySampleLength, and is left out when
+// HS_METADATA_ONLY is set.
+func (cfg config) replyAttributes(reply bson.Raw) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if n, ok := lookupInt64(reply, "n"); ok {
+		attrs = append(attrs, ReplyNKey.Int64(n))
+	}
+	if n, ok := lookupInt64(reply, "nModified"); ok {
+		attrs = append(attrs, ReplyNModifiedKey.Int64(n))
+	}
+	if id, ok := replyCursorID(reply); ok {
+		attrs = append(attrs, CursorIDKey.Int64(id))
+	}
+	if size, ok := replyBatchSize(reply); ok {
+		attrs = append(attrs, ReplyBatchSizeKey.Int(size))
+	}
+
+	if os.Getenv("HS_METADATA_ONLY") != "true" {
+		b, _ := bson.MarshalExtJSON(reply, false, false)
+		attr := datautils.ObfuscateAttributeValue(ReplySampleKey.String(string(b)))
+		maxLength := cfg.CommandMaxLength
+		if maxLength <= 0 || maxLength > maxReplySampleLength {
+			maxLength = maxReplySampleLength
+		}
+		attr.Value = attribute.StringValue(truncate(attr.Value.AsString(), maxLength))
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+// replyCursorID returns the id of the cursor opened or continued by a
+// command. An id of zero means the cursor is exhausted.
+func replyCursorID(reply bson.Raw) (int64, bool) {
+	return lookupInt64(reply, "cursor", "id")
+}
+
+// replyBatchSize returns the number of documents returned in a cursor batch.
+func replyBatchSize(reply bson.Raw) (int, bool) {
+	for _, batch := range []string{"firstBatch", "nextBatch"} {
+		v, err := reply.LookupErr("cursor", batch)
+		if err != nil || v.Type != bsontype.Array {
+			continue
+		}
+		values, err := bson.Raw(v.Value).Values()
+		if err != nil {
+			return 0, false
+		}
+		return len(values), true
+	}
+	return 0, false
+}
+
+func lookupInt64(doc bson.Raw, key ...string) (int64, bool) {
+	v, err := doc.LookupErr(key...)
+	if err != nil {
+		return 0, false
+	}
+	switch v.Type {
+	case bsontype.Int32:
+		return int64(v.Int32()), true
+	case bsontype.Int64:
+		return v.Int64(), true
+	case bsontype.Double:
+		return int64(v.Double()), true
+	}
+	return 0, false
+}
+
+// trackedCursor is an open cursor and the span context of the command that
+// opened it.
+type trackedCursor struct {
+	key cursorKey
+	sc  trace.SpanContext
+}
+
+// cursorLink returns a link to the span of the command that opened the
+// cursor on the server at address continued by a getMore command, if known.
+func (m *monitor) cursorLink(address string, id int64) (trace.Link, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	e, ok := m.cursors[cursorKey{Address: address, ID: id}]
+	if !ok {
+		return trace.Link{}, false
+	}
+	return trace.Link{
+		SpanContext: e.Value.(trackedCursor).sc,
+		Attributes:  []attribute.KeyValue{CursorIDKey.Int64(id)},
+	}, true
+}
+
+// trackCursor records the cursor returned in reply to cmd by the server at
+// address. The span of the command opening a cursor is remembered until the
+// cursor is exhausted so that later getMore spans can be linked to it. At
+// most maxTrackedCursors cursors are remembered: the oldest one is forgotten
+// to track a new one, which bounds memory when cursors are abandoned.
+func (m *monitor) trackCursor(address string, cmd command, reply bson.Raw) {
+	id, ok := replyCursorID(reply)
+	if !ok {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	switch {
+	case id == 0 && cmd.cursorID != 0:
+		m.untrackCursor(cursorKey{Address: address, ID: cmd.cursorID})
+	case id != 0 && cmd.cursorID == 0:
+		key := cursorKey{Address: address, ID: id}
+		m.untrackCursor(key)
+		if len(m.cursors) >= maxTrackedCursors {
+			m.untrackCursor(m.cursorOrder.Front().Value.(trackedCursor).key)
+		}
+		m.cursors[key] = m.cursorOrder.PushBack(trackedCursor{key: key, sc: cmd.span.SpanContext()})
+	}
+}
+
+// untrackCursor forgets the cursor key, if tracked. The monitor must be
+// locked.
+func (m *monitor) untrackCursor(key cursorKey) {
+	if e, ok := m.cursors[key]; ok {
+		m.cursorOrder.Remove(e)
+		delete(m.cursors, key)
+	}
+}
+
+// forgetCursors stops tracking the cursors closed by a killCursors command
+// sent to the server at address.
+func (m *monitor) forgetCursors(address string, command bson.Raw) {
+	v, err := command.LookupErr("cursors")
+	if err != nil || v.Type != bsontype.Array {
+		return
+	}
+	values, err := bson.Raw(v.Value).Values()
+	if err != nil {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for _, v := range values {
+		if v.Type == bsontype.Int64 {
+			m.untrackCursor(cursorKey{Address: address, ID: v.Int64()})
+		}
+	}
+}
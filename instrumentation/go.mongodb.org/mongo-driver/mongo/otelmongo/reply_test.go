@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestReplyAttributes(t *testing.T) {
+	cfg := newConfig(WithReplyCapture())
+
+	update := marshal(t, bson.D{{Key: "n", Value: int32(3)}, {Key: "nModified", Value: int32(2)}, {Key: "ok", Value: 1.0}})
+	attrs := cfg.replyAttributes(update)
+	assert.Contains(t, attrs, ReplyNKey.Int64(3))
+	assert.Contains(t, attrs, ReplyNModifiedKey.Int64(2))
+
+	find := marshal(t, bson.D{
+		{Key: "cursor", Value: bson.D{
+			{Key: "firstBatch", Value: bson.A{bson.D{{Key: "a", Value: 1}}, bson.D{{Key: "a", Value: 2}}}},
+			{Key: "id", Value: int64(42)},
+			{Key: "ns", Value: "test.users"},
+		}},
+		{Key: "ok", Value: 1.0},
+	})
+	attrs = cfg.replyAttributes(find)
+	assert.Contains(t, attrs, CursorIDKey.Int64(42))
+	assert.Contains(t, attrs, ReplyBatchSizeKey.Int(2))
+
+	cfg = newConfig(WithReplyCapture(), WithCommandMaxLength(10))
+	attrs = cfg.replyAttributes(find)
+	sample := attrs[len(attrs)-1]
+	assert.Equal(t, ReplySampleKey, sample.Key)
+	assert.Len(t, sample.Value.AsString(), 10)
+
+	large := marshal(t, bson.D{{Key: "cursor", Value: bson.D{
+		{Key: "firstBatch", Value: bson.A{bson.D{{Key: "a", Value: strings.Repeat("x", 2*maxReplySampleLength)}}}},
+		{Key: "id", Value: int64(0)},
+	}}})
+	cfg = newConfig(WithReplyCapture(), WithCommandMaxLength(0))
+	attrs = cfg.replyAttributes(large)
+	sample = attrs[len(attrs)-1]
+	assert.Equal(t, ReplySampleKey, sample.Key)
+	assert.Len(t, sample.Value.AsString(), maxReplySampleLength)
+
+	t.Setenv("HS_METADATA_ONLY", "true")
+	for _, attr := range cfg.replyAttributes(find) {
+		assert.NotEqual(t, ReplySampleKey, attr.Key)
+	}
+}
+
+func TestCursorCorrelation(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	cfg := newConfig(WithTracerProvider(provider), WithCursorCorrelation())
+	m := newMonitor(cfg)
+	ctx := context.Background()
+
+	run := func(connectionID string, requestID int64, name string, cmd, reply bson.D) {
+		m.Started(ctx, &event.CommandStartedEvent{
+			Command:      marshal(t, cmd),
+			CommandName:  name,
+			ConnectionID: connectionID,
+			RequestID:    requestID,
+		})
+		m.Succeeded(ctx, &event.CommandSucceededEvent{
+			CommandFinishedEvent: event.CommandFinishedEvent{
+				CommandName:  name,
+				ConnectionID: connectionID,
+				RequestID:    requestID,
+			},
+			Reply: marshal(t, reply),
+		})
+	}
+
+	first := cursorKey{Address: "localhost:27017", ID: 42}
+	second := cursorKey{Address: "localhost:27018", ID: 42}
+
+	run("localhost:27017[-1]", 1, "find", bson.D{{Key: "find", Value: "users"}}, bson.D{{Key: "cursor", Value: bson.D{{Key: "id", Value: int64(42)}}}})
+	run("localhost:27018[-1]", 1, "find", bson.D{{Key: "find", Value: "users"}}, bson.D{{Key: "cursor", Value: bson.D{{Key: "id", Value: int64(42)}}}})
+	assert.Contains(t, m.cursors, first)
+	assert.Contains(t, m.cursors, second)
+
+	run("localhost:27017[-2]", 2, "getMore", bson.D{{Key: "getMore", Value: int64(42)}}, bson.D{{Key: "cursor", Value: bson.D{{Key: "id", Value: int64(42)}}}})
+	assert.Contains(t, m.cursors, first)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	getMore := spans[2]
+	require.Len(t, getMore.Links(), 1)
+	assert.Equal(t, spans[0].SpanContext(), getMore.Links()[0].SpanContext)
+	assert.Contains(t, getMore.Links()[0].Attributes, CursorIDKey.Int64(42))
+
+	run("localhost:27017[-1]", 3, "getMore", bson.D{{Key: "getMore", Value: int64(42)}}, bson.D{{Key: "cursor", Value: bson.D{{Key: "id", Value: int64(0)}}}})
+	assert.NotContains(t, m.cursors, first)
+	assert.Contains(t, m.cursors, second)
+
+	run("localhost:27018[-1]", 4, "killCursors", bson.D{{Key: "killCursors", Value: "users"}, {Key: "cursors", Value: bson.A{int64(42)}}}, bson.D{})
+	assert.Empty(t, m.cursors)
+	assert.Empty(t, m.spans)
+}
+
+func TestTrackCursorEvictsOldest(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	m := newMonitor(newConfig(WithTracerProvider(provider), WithCursorCorrelation()))
+	_, span := provider.Tracer("test").Start(context.Background(), "find")
+	cmd := command{span: span}
+
+	for id := int64(1); id <= maxTrackedCursors+1; id++ {
+		reply := marshal(t, bson.D{{Key: "cursor", Value: bson.D{{Key: "id", Value: id}}}})
+		m.trackCursor("localhost:27017", cmd, reply)
+	}
+	assert.Len(t, m.cursors, maxTrackedCursors)
+	assert.Equal(t, maxTrackedCursors, m.cursorOrder.Len())
+	assert.NotContains(t, m.cursors, cursorKey{Address: "localhost:27017", ID: 1})
+	assert.Contains(t, m.cursors, cursorKey{Address: "localhost:27017", ID: maxTrackedCursors + 1})
+}
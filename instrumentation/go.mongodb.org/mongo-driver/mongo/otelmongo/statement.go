@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	datautils "github.com/helios/go-sdk/data-utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DefaultCommandMaxLength is the maximum length, in bytes, of the db.statement
+// attribute when no length is configured with WithCommandMaxLength.
+const DefaultCommandMaxLength = 4096
+
+// placeholder replaces literal values in sanitized commands.
+const placeholder = `"?"`
+
+// statement returns the db.statement attribute of a command run against
+// collection. Commands are sanitized unless the collection was configured with
+// WithRawCommandCollections, in which case the raw command is obfuscated.
+func (cfg config) statement(command bson.Raw, collection string) attribute.KeyValue {
+	if _, ok := cfg.RawCommandCollections[collection]; ok && collection != "" && os.Getenv("HS_METADATA_ONLY") != "true" {
+		b, _ := bson.MarshalExtJSON(command, false, false)
+		attr := datautils.ObfuscateAttributeValue(semconv.DBStatementKey.String(string(b)))
+		attr.Value = attribute.StringValue(truncate(attr.Value.AsString(), cfg.CommandMaxLength))
+		return attr
+	}
+	return semconv.DBStatementKey.String(sanitizeCommand(command, cfg.CommandMaxLength))
+}
+
+// sanitizeCommand renders command as JSON with every literal value replaced
+// by "?". Field names, operators and the collection the command targets are
+// kept. The result is truncated to maxLength bytes unless maxLength is zero or
+// less.
+func sanitizeCommand(command bson.Raw, maxLength int) string {
+	w := &statementWriter{maxLength: maxLength}
+	w.document(command, true)
+	return truncate(w.String(), maxLength)
+}
+
+// statementWriter builds a sanitized command, stopping early once the
+// maximum length is reached so large inserts are not walked entirely.
+type statementWriter struct {
+	strings.Builder
+	maxLength int
+}
+
+func (w *statementWriter) full() bool {
+	return w.maxLength > 0 && w.Len() > w.maxLength
+}
+
+// document writes doc. When command is true, the value of the first element
+// is kept if it is a string, as it holds the name of the targeted collection.
+func (w *statementWriter) document(doc bson.Raw, command bool) {
+	elems, err := doc.Elements()
+	if err != nil {
+		w.WriteString(placeholder)
+		return
+	}
+
+	w.WriteByte('{')
+	for i, elem := range elems {
+		if w.full() {
+			break
+		}
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		key, _ := json.Marshal(elem.Key())
+		w.Write(key)
+		w.WriteByte(':')
+
+		v := elem.Value()
+		if i == 0 && command && v.Type == bsontype.String {
+			s, _ := json.Marshal(v.StringValue())
+			w.Write(s)
+			continue
+		}
+		w.value(v)
+	}
+	w.WriteByte('}')
+}
+
+func (w *statementWriter) value(v bson.RawValue) {
+	switch v.Type {
+	case bsontype.EmbeddedDocument:
+		w.document(bson.Raw(v.Value), false)
+	case bsontype.Array:
+		// Arrays are encoded as documents keyed by index.
+		values, err := bson.Raw(v.Value).Values()
+		if err != nil {
+			w.WriteString(placeholder)
+			return
+		}
+		w.WriteByte('[')
+		for i, elem := range values {
+			if w.full() {
+				break
+			}
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.value(elem)
+		}
+		w.WriteByte(']')
+	default:
+		w.WriteString(placeholder)
+	}
+}
+
+// truncate cuts s to at most maxLength bytes on a UTF-8 boundary. A
+// maxLength of zero or less disables truncation.
+func truncate(s string, maxLength int) string {
+	if maxLength <= 0 || len(s) <= maxLength {
+		return s
+	}
+	cut := maxLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func marshal(t *testing.T, doc bson.D) bson.Raw {
+	t.Helper()
+	b, err := bson.Marshal(doc)
+	require.NoError(t, err)
+	return b
+}
+
+func TestSanitizeCommand(t *testing.T) {
+	testCases := []struct {
+		name    string
+		command bson.D
+		want    string
+	}{
+		{
+			name: "insert",
+			command: bson.D{
+				{Key: "insert", Value: "users"},
+				{Key: "documents", Value: bson.A{
+					bson.D{{Key: "name", Value: "alice"}, {Key: "age", Value: 42}},
+				}},
+				{Key: "ordered", Value: true},
+			},
+			want: `{"insert":"users","documents":[{"name":"?","age":"?"}],"ordered":"?"}`,
+		},
+		{
+			name: "find with operators",
+			command: bson.D{
+				{Key: "find", Value: "users"},
+				{Key: "filter", Value: bson.D{
+					{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}},
+					{Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"a", "b"}}}},
+				}},
+			},
+			want: `{"find":"users","filter":{"age":{"$gt":"?"},"tags":{"$in":["?","?"]}}}`,
+		},
+		{
+			name:    "non-string first value",
+			command: bson.D{{Key: "ping", Value: 1}},
+			want:    `{"ping":"?"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sanitizeCommand(marshal(t, tc.command), 0))
+		})
+	}
+}
+
+func TestSanitizeCommandMaxLength(t *testing.T) {
+	docs := make(bson.A, 1000)
+	for i := range docs {
+		docs[i] = bson.D{{Key: "value", Value: i}}
+	}
+	command := marshal(t, bson.D{{Key: "insert", Value: "users"}, {Key: "documents", Value: docs}})
+
+	got := sanitizeCommand(command, 64)
+	assert.Len(t, got, 64)
+	assert.True(t, strings.HasPrefix(got, `{"insert":"users","documents":[{"value":"?"},`))
+}
+
+func TestStatement(t *testing.T) {
+	command := marshal(t, bson.D{
+		{Key: "insert", Value: "audit"},
+		{Key: "documents", Value: bson.A{bson.D{{Key: "event", Value: "login"}}}},
+	})
+
+	cfg := newConfig()
+	attr := cfg.statement(command, "audit")
+	assert.Equal(t, semconv.DBStatementKey, attr.Key)
+	assert.NotContains(t, attr.Value.AsString(), "login")
+
+	cfg = newConfig(WithRawCommandCollections("audit"))
+	assert.Contains(t, cfg.statement(command, "audit").Value.AsString(), `"event":"login"`)
+	assert.NotContains(t, cfg.statement(command, "other").Value.AsString(), "login")
+
+	t.Setenv("HS_METADATA_ONLY", "true")
+	assert.NotContains(t, cfg.statement(command, "audit").Value.AsString(), "login")
+}
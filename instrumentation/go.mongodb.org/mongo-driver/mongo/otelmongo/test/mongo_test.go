@@ -21,7 +21,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -29,6 +31,7 @@ import (
 	"go.opentelemetry.io/contrib/internal/util"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
@@ -72,7 +75,7 @@ func TestDBCrudOperation(t *testing.T) {
 			validators: append(commonValidators, func(s sdktrace.ReadOnlySpan) bool {
 				for _, attr := range s.Attributes() {
 					if attr.Key == "db.statement" {
-						return assert.Contains(t, attr.Value.AsString(), `"test-item":"test-value"`)
+						return assert.Contains(t, attr.Value.AsString(), `"test-item":"?"`)
 					}
 				}
 				return false
@@ -249,3 +252,39 @@ func TestDBCollectionAttribute(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapCollectionComment(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	propagators := otelmongo.WithPropagators(propagation.TraceContext{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	ctx, span := provider.Tracer("test").Start(ctx, "mongodb-test")
+	defer span.End()
+
+	var comments []bson.RawValue
+	monitor := otelmongo.NewMonitor(otelmongo.WithTracerProvider(provider))
+	started := monitor.Started
+	monitor.Started = func(ctx context.Context, evt *event.CommandStartedEvent) {
+		comments = append(comments, evt.Command.Lookup("comment"))
+		started(ctx, evt)
+	}
+
+	opts := options.Client()
+	opts.Monitor = monitor
+	opts.ApplyURI("mongodb://localhost:27017/?connect=direct")
+	client, err := mongo.Connect(ctx, opts)
+	require.NoError(t, err)
+
+	coll := otelmongo.WrapCollection(client.Database("test-database").Collection("test-collection"), propagators)
+	_, err = coll.InsertOne(ctx, bson.D{{Key: "test-item", Value: "test-value"}})
+	require.NoError(t, err)
+	_, err = coll.DeleteOne(ctx, bson.D{{Key: "test-item", Value: "test-value"}}, options.Delete().SetComment("user comment"))
+	require.NoError(t, err)
+
+	require.Len(t, comments, 2)
+	assert.Equal(t, otelmongo.Comment(ctx, propagators), comments[0].StringValue())
+	assert.Equal(t, "user comment", comments[1].StringValue())
+}
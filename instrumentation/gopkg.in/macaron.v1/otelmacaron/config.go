@@ -15,17 +15,31 @@
 package otelmacaron // import "go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron"
 
 import (
+	"net/http"
+
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // config is a group of options for this instrumentation.
 type config struct {
-	TracerProvider trace.TracerProvider
-	Propagators    propagation.TextMapPropagator
+	TracerProvider    trace.TracerProvider
+	MeterProvider     metric.MeterProvider
+	Propagators       propagation.TextMapPropagator
+	Filters           []Filter
+	SpanNameFormatter func(string, *http.Request) string
+	PublicEndpoint    bool
+	PublicEndpointFn  func(*http.Request) bool
+	SpanStartOptions  []trace.SpanStartOption
 }
 
+// Filter is a predicate used to determine whether a given http.request should
+// be traced. A Filter must return true if the request should be traced.
+type Filter func(*http.Request) bool
+
 // Option applies an option value for a config.
 type Option interface {
 	apply(*config)
@@ -36,6 +50,7 @@ func newConfig(opts []Option) *config {
 	c := &config{
 		Propagators:    otel.GetTextMapPropagator(),
 		TracerProvider: otel.GetTracerProvider(),
+		MeterProvider:  global.MeterProvider(),
 	}
 	for _, o := range opts {
 		o.apply(c)
@@ -70,3 +85,88 @@ func (o tracerProviderOption) apply(c *config) {
 func WithTracerProvider(tp trace.TracerProvider) Option {
 	return tracerProviderOption{tp: tp}
 }
+
+type meterProviderOption struct{ mp metric.MeterProvider }
+
+func (o meterProviderOption) apply(c *config) {
+	if o.mp != nil {
+		c.MeterProvider = o.mp
+	}
+}
+
+// WithMeterProvider returns an Option to use the MeterProvider when
+// creating a Meter.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return meterProviderOption{mp: mp}
+}
+
+type filterOption struct{ f Filter }
+
+func (o filterOption) apply(c *config) {
+	if o.f != nil {
+		c.Filters = append(c.Filters, o.f)
+	}
+}
+
+// WithFilter returns an Option adding a filter to the list of filters used by
+// the middleware. If any filter indicates to exclude a request then the
+// request will not be traced. All filters must allow a request to be traced
+// for a Span to be created. If no filters are provided then all requests are
+// traced. Filters will be invoked for each processed request, it is advised
+// to make them simple and fast.
+func WithFilter(f Filter) Option {
+	return filterOption{f: f}
+}
+
+type spanNameFormatterOption struct {
+	f func(string, *http.Request) string
+}
+
+func (o spanNameFormatterOption) apply(c *config) {
+	c.SpanNameFormatter = o.f
+}
+
+// WithSpanNameFormatter returns an Option to use the function f to name the
+// spans. The function is given the default span name, the request URI, and
+// is called on every request.
+func WithSpanNameFormatter(f func(route string, r *http.Request) string) Option {
+	return spanNameFormatterOption{f: f}
+}
+
+type publicEndpointOption struct{}
+
+func (o publicEndpointOption) apply(c *config) {
+	c.PublicEndpoint = true
+}
+
+// WithPublicEndpoint returns an Option making the macaron middleware start
+// the span of each request as a new root, linked to the incoming span context
+// instead of parented to it.
+func WithPublicEndpoint() Option {
+	return publicEndpointOption{}
+}
+
+type publicEndpointFnOption struct{ fn func(*http.Request) bool }
+
+func (o publicEndpointFnOption) apply(c *config) {
+	c.PublicEndpointFn = o.fn
+}
+
+// WithPublicEndpointFn returns an Option making the macaron middleware start
+// a new root for the requests fn returns true for. WithPublicEndpoint, if
+// set, applies to all requests regardless of fn.
+func WithPublicEndpointFn(fn func(*http.Request) bool) Option {
+	return publicEndpointFnOption{fn: fn}
+}
+
+type spanStartOptionsOption struct{ opts []trace.SpanStartOption }
+
+func (o spanStartOptionsOption) apply(c *config) {
+	c.SpanStartOptions = append(c.SpanStartOptions, o.opts...)
+}
+
+// WithSpanOptions returns an Option to apply an additional set of
+// trace.SpanStartOptions to each new span.
+func WithSpanOptions(opts ...trace.SpanStartOption) Option {
+	return spanStartOptionsOption{opts: opts}
+}
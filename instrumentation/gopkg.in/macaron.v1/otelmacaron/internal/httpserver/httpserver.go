@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpserver records the HTTP server metrics and span attributes of
+// the macaron middleware.
+package httpserver // import "go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron/internal/httpserver"
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// Server HTTP metrics.
+const (
+	ServerLatency         = "http.server.duration"                // Incoming end to end duration, milliseconds
+	RequestContentLength  = "http.server.request_content_length"  // Incoming request bytes total
+	ResponseContentLength = "http.server.response_content_length" // Outgoing response bytes total
+	ActiveRequests        = "http.server.active_requests"         // Number of requests being served
+)
+
+// Instruments records the server HTTP metrics.
+type Instruments struct {
+	// duration is the time taken to serve requests.
+	duration syncfloat64.Histogram
+
+	// requestSize is the number of bytes read from request bodies.
+	requestSize syncint64.Counter
+
+	// responseSize is the number of bytes written to response bodies.
+	responseSize syncint64.Counter
+
+	// active is the number of requests being served.
+	active syncint64.UpDownCounter
+}
+
+// NewInstruments will create instruments using the given meter.
+func NewInstruments(meter metric.Meter) *Instruments {
+	instruments := &Instruments{}
+	var err error
+
+	if instruments.duration, err = meter.SyncFloat64().Histogram(
+		ServerLatency,
+		instrument.WithDescription("Time taken to serve requests"),
+		instrument.WithUnit(unit.Milliseconds),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.requestSize, err = meter.SyncInt64().Counter(
+		RequestContentLength,
+		instrument.WithDescription("Number of bytes read from request bodies"),
+		instrument.WithUnit(unit.Bytes),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.responseSize, err = meter.SyncInt64().Counter(
+		ResponseContentLength,
+		instrument.WithDescription("Number of bytes written to response bodies"),
+		instrument.WithUnit(unit.Bytes),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	if instruments.active, err = meter.SyncInt64().UpDownCounter(
+		ActiveRequests,
+		instrument.WithDescription("Number of requests being served"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return instruments
+}
+
+// RequestAttrs returns the attributes identifying the requests of a route.
+func RequestAttrs(service, route string, r *http.Request) []attribute.KeyValue {
+	attrs := semconv.HTTPServerMetricAttributesFromHTTPRequest(service, r)
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRouteKey.String(route))
+	}
+	return attrs
+}
+
+// Started records the start of a request with attrs. The returned function
+// records its completion with the response status code and the number of
+// bytes read and written. It must be called exactly once, usually deferred
+// so that requests whose handler panics are not left active.
+func (i *Instruments) Started(ctx context.Context, attrs []attribute.KeyValue) func(status int, read, written int64) {
+	start := time.Now()
+	i.active.Add(ctx, 1, attrs...)
+	return func(status int, read, written int64) {
+		i.active.Add(ctx, -1, attrs...)
+
+		statusAttrs := append(attrs[:len(attrs):len(attrs)], semconv.HTTPStatusCodeKey.Int(status))
+		i.requestSize.Add(ctx, read, statusAttrs...)
+		i.responseSize.Add(ctx, written, statusAttrs...)
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		i.duration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), statusAttrs...)
+	}
+}
@@ -15,11 +15,20 @@
 package otelmacaron // import "go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron"
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"os"
 
+	datautils "github.com/helios/go-sdk/data-utils"
 	"gopkg.in/macaron.v1"
 
+	"go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron/internal/httpserver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -28,6 +37,72 @@ import (
 // instrumentationName is the name of this instrumentation package.
 const instrumentationName = "go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron"
 
+var _ io.ReadCloser = &bodyWrapper{}
+
+// bodyWrapper wraps a http.Request.Body (an io.ReadCloser) to track the number
+// of bytes read and collect the content read by the handlers.
+type bodyWrapper struct {
+	io.ReadCloser
+	read         int64
+	requestBody  []byte
+	metadataOnly bool
+	contentType  string
+}
+
+func (w *bodyWrapper) Read(b []byte) (int, error) {
+	n, err := w.ReadCloser.Read(b)
+	if n > 0 && !w.metadataOnly {
+		shouldSkipContentByType, _ := datautils.ShouldSkipContentCollectionByContentType(w.contentType)
+		if !shouldSkipContentByType {
+			w.requestBody = append(w.requestBody, b[0:n]...)
+		}
+	}
+	w.read += int64(n)
+	return n, err
+}
+
+func (w *bodyWrapper) Close() error {
+	return w.ReadCloser.Close()
+}
+
+var _ macaron.ResponseWriter = &recordingResponseWriter{}
+
+// recordingResponseWriter wraps a macaron.ResponseWriter to collect the
+// content written by the handlers.
+type recordingResponseWriter struct {
+	macaron.ResponseWriter
+	responseBody []byte
+	metadataOnly bool
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	if !w.metadataOnly && n > 0 {
+		shouldSkipContentByType, _ := datautils.ShouldSkipContentCollectionByContentType(w.Header().Get("Content-Type"))
+		if !shouldSkipContentByType {
+			w.responseBody = append(w.responseBody, b[:n]...)
+		}
+	}
+	return n, err
+}
+
+// Hijack implements the http.Hijacker interface when the wrapped writer does.
+func (w *recordingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("the ResponseWriter doesn't support the Hijacker interface")
+	}
+	return hijacker.Hijack()
+}
+
+func collectRequestHeaders(r *http.Request, span oteltrace.Span) {
+	headersStr, err := json.Marshal(r.Header)
+	if err == nil {
+		attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.request.headers", Value: attribute.StringValue(string(headersStr))})
+		span.SetAttributes(attr)
+	}
+}
+
 // Middleware returns a macaron Handler to trace requests to the server.
 func Middleware(service string, opts ...Option) macaron.Handler {
 	cfg := newConfig(opts)
@@ -35,10 +110,25 @@ func Middleware(service string, opts ...Option) macaron.Handler {
 		instrumentationName,
 		oteltrace.WithInstrumentationVersion(SemVersion()),
 	)
+	instruments := httpserver.NewInstruments(
+		cfg.MeterProvider.Meter(instrumentationName, metric.WithInstrumentationVersion(SemVersion())),
+	)
 	return func(res http.ResponseWriter, req *http.Request, c *macaron.Context) {
+		for _, f := range cfg.Filters {
+			if !f(c.Req.Request) {
+				// Serve the request to the next middleware
+				// if a filter rejects the request.
+				c.Next()
+				return
+			}
+		}
+
 		savedCtx := c.Req.Request.Context()
+		savedResp := c.Resp
 		defer func() {
 			c.Req.Request = c.Req.Request.WithContext(savedCtx)
+			c.Resp = savedResp
+			c.MapTo(savedResp, (*http.ResponseWriter)(nil))
 		}()
 
 		ctx := cfg.Propagators.Extract(savedCtx, propagation.HeaderCarrier(c.Req.Header))
@@ -48,11 +138,31 @@ func Middleware(service string, opts ...Option) macaron.Handler {
 			oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(service, "", c.Req.Request)...),
 			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 		}
+		opts = append(opts, cfg.SpanStartOptions...)
+		opts = append(opts, httpserver.PublicEndpoint(ctx, c.Req.Request, cfg.PublicEndpoint, cfg.PublicEndpointFn)...)
 		// TODO: span name should be router template not the actual request path, eg /user/:id vs /user/123
 		spanName := c.Req.RequestURI
 		if spanName == "" {
 			spanName = fmt.Sprintf("HTTP %s route not found", c.Req.Method)
 		}
+		if cfg.SpanNameFormatter != nil {
+			spanName = cfg.SpanNameFormatter(spanName, c.Req.Request)
+		}
+		metadataOnly := os.Getenv("HS_METADATA_ONLY") == "true"
+		var bw bodyWrapper
+		if c.Req.Request.Body != nil && c.Req.Request.Body != http.NoBody {
+			bw.ReadCloser = c.Req.Request.Body
+			bw.metadataOnly = metadataOnly
+			bw.contentType = c.Req.Header.Get("Content-Type")
+			c.Req.Request.Body = &bw
+		}
+		rrw := &recordingResponseWriter{ResponseWriter: c.Resp, metadataOnly: metadataOnly}
+		c.Resp = rrw
+		c.MapTo(rrw, (*http.ResponseWriter)(nil))
+
+		// macaron does not expose the matched route pattern.
+		finished := instruments.Started(ctx, httpserver.RequestAttrs(service, "", c.Req.Request))
+		defer func() { finished(c.Resp.Status(), bw.read, int64(c.Resp.Size())) }()
 		ctx, span := tracer.Start(ctx, spanName, opts...)
 		defer span.End()
 
@@ -67,5 +177,18 @@ func Middleware(service string, opts ...Option) macaron.Handler {
 		spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, oteltrace.SpanKindServer)
 		span.SetAttributes(attrs...)
 		span.SetStatus(spanStatus, spanMessage)
+
+		if !metadataOnly {
+			collectRequestHeaders(c.Req.Request, span)
+			if len(bw.requestBody) > 0 {
+				attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.request.body", Value: attribute.StringValue(string(bw.requestBody))})
+				span.SetAttributes(attr)
+			}
+
+			if len(rrw.responseBody) > 0 {
+				attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.response.body", Value: attribute.StringValue(string(rrw.responseBody))})
+				span.SetAttributes(attr)
+			}
+		}
 	}
 }
@@ -41,6 +41,7 @@ type config struct {
 	PublicEndpointFn  func(*http.Request) bool
 	ReadEvent         bool
 	WriteEvent        bool
+	WebsocketEvent    bool
 	Filters           []Filter
 	SpanNameFormatter func(string, *http.Request) string
 	ClientTrace       func(context.Context) *httptrace.ClientTrace
@@ -159,6 +160,7 @@ type event int
 const (
 	ReadEvents event = iota
 	WriteEvents
+	WebsocketEvents
 )
 
 // WithMessageEvents configures the Handler to record the specified events
@@ -170,6 +172,9 @@ const (
 //     using the ReadBytesKey
 //   - WriteEvents: Record the number of bytes written after every http.ResponeWriter.Write
 //     using the WriteBytesKey
+//   - WebsocketEvents: Record the type and size of every message sent and received
+//     over a websocket session, using the WebsocketMessageTypeKey and
+//     WebsocketMessageSizeKey
 func WithMessageEvents(events ...event) Option {
 	return optionFunc(func(c *config) {
 		for _, e := range events {
@@ -178,6 +183,8 @@ func WithMessageEvents(events ...event) Option {
 				c.ReadEvent = true
 			case WriteEvents:
 				c.WriteEvent = true
+			case WebsocketEvents:
+				c.WebsocketEvent = true
 			}
 		}
 	})
@@ -15,11 +15,14 @@
 package otelhttp // import "github.com/helios/opentelemetry-go-contrib/instrumentation/net/http/otelhttp"
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/felixge/httpsnoop"
@@ -51,6 +54,7 @@ type Handler struct {
 	spanStartOptions  []trace.SpanStartOption
 	readEvent         bool
 	writeEvent        bool
+	websocketEvent    bool
 	filters           []Filter
 	spanNameFormatter func(string, *http.Request) string
 	counters          map[string]syncint64.Counter
@@ -90,6 +94,7 @@ func (h *Handler) configure(c *config) {
 	h.spanStartOptions = c.SpanStartOptions
 	h.readEvent = c.ReadEvent
 	h.writeEvent = c.WriteEvent
+	h.websocketEvent = c.WebsocketEvent
 	h.filters = c.Filters
 	h.spanNameFormatter = c.SpanNameFormatter
 	h.publicEndpoint = c.PublicEndpoint
@@ -212,6 +217,50 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		rww.Header().Add("traceresponse", fmt.Sprintf("00-%s-%s-01", spanCtx.TraceID().String(), spanCtx.SpanID().String()))
 	}
 
+	labeler := &Labeler{}
+	ctx = injectLabeler(ctx, labeler)
+	ctx = contextWithSessionHolder(ctx)
+
+	// finish completes the HTTP span and records the metrics of the request,
+	// once the handler returns or writes the status line answering an
+	// upgrade request over the hijacked connection, possibly from another
+	// goroutine.
+	var finishMu sync.Mutex
+	finished := false
+	finish := func(statusCode int) {
+		finishMu.Lock()
+		defer finishMu.Unlock()
+		if finished {
+			return
+		}
+		finished = true
+
+		setAfterServeAttributes(span, bw.read, rww.written, statusCode, bw.err, rww.err)
+		if !metadataOnly {
+			collectRequestHeaders(r, span)
+			if len(bw.requestBody) > 0 {
+				attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.request.body", Value: attribute.StringValue(string(bw.requestBody))})
+				span.SetAttributes(attr)
+			}
+
+			if len(rww.responseBody) > 0 {
+				attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.response.body", Value: attribute.StringValue(string(rww.responseBody))})
+				span.SetAttributes(attr)
+			}
+		}
+		span.End()
+
+		// Add metrics
+		attributes := append(labeler.Get(), semconv.HTTPServerMetricAttributesFromHTTPRequest(h.operation, r)...)
+		h.counters[RequestContentLength].Add(ctx, bw.read, attributes...)
+		h.counters[ResponseContentLength].Add(ctx, rww.written, attributes...)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+
+		h.valueRecorders[ServerLatency].Record(ctx, elapsedTime, attributes...)
+	}
+
 	// Wrap w to use our ResponseWriter methods while also exposing
 	// other interfaces that w may implement (http.CloseNotifier,
 	// http.Flusher, http.Hijacker, http.Pusher, io.ReaderFrom).
@@ -226,36 +275,31 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		WriteHeader: func(httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
 			return rww.WriteHeader
 		},
+		Hijack: func(next httpsnoop.HijackFunc) httpsnoop.HijackFunc {
+			return func() (net.Conn, *bufio.ReadWriter, error) {
+				conn, brw, err := next()
+				if err != nil || !isWebsocketUpgrade(r.Header) {
+					return conn, brw, err
+				}
+				// The handler answers the upgrade over the hijacked
+				// connection: the HTTP exchange ends with the status
+				// line it writes, and the websocket session only starts
+				// if the upgrade is accepted.
+				conn, brw = newSessionConn(conn, brw, func(statusCode int) *websocketSession {
+					finish(statusCode)
+					if statusCode != http.StatusSwitchingProtocols {
+						return nil
+					}
+					return startWebsocketSession(ctx, tracer, trace.SpanKindServer, h.websocketEvent, true)
+				})
+				return conn, brw, nil
+			}
+		},
 	})
 
-	labeler := &Labeler{}
-	ctx = injectLabeler(ctx, labeler)
-
 	h.handler.ServeHTTP(w, r.WithContext(ctx))
 
-	setAfterServeAttributes(span, bw.read, rww.written, rww.statusCode, bw.err, rww.err)
-	if !metadataOnly {
-		collectRequestHeaders(r, span)
-		if len(bw.requestBody) > 0 {
-			attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.request.body", Value: attribute.StringValue(string(bw.requestBody))})
-			span.SetAttributes(attr)
-		}
-
-		if len(rww.responseBody) > 0 {
-			attr := datautils.ObfuscateAttributeValue(attribute.KeyValue{Key: "http.response.body", Value: attribute.StringValue(string(rww.responseBody))})
-			span.SetAttributes(attr)
-		}
-	}
-
-	// Add metrics
-	attributes := append(labeler.Get(), semconv.HTTPServerMetricAttributesFromHTTPRequest(h.operation, r)...)
-	h.counters[RequestContentLength].Add(ctx, bw.read, attributes...)
-	h.counters[ResponseContentLength].Add(ctx, rww.written, attributes...)
-
-	// Use floating point division here for higher precision (instead of Millisecond method).
-	elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
-
-	h.valueRecorders[ServerLatency].Record(ctx, elapsedTime, attributes...)
+	finish(rww.statusCode)
 }
 
 func setAfterServeAttributes(span trace.Span, read, wrote int64, statusCode int, rerr, werr error) {
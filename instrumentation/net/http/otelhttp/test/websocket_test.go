@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helios/opentelemetry-go-contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maskedFrame is a masked text frame carrying "hello", as sent by clients.
+var maskedFrame = []byte{0x81, 0x85, 0x37, 0xfa, 0x21, 0x3d, 0x5f, 0x9f, 0x4d, 0x51, 0x58}
+
+// unmaskedFrame is a text frame carrying "world", as sent by servers.
+var unmaskedFrame = []byte{0x81, 0x05, 'w', 'o', 'r', 'l', 'd'}
+
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string, kind trace.SpanKind) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if span.Name() == name && span.SpanKind() == kind {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "%s (%s)", name, kind)
+	return nil
+}
+
+func TestWebsocketUpgrade(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	done := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = brw.WriteString(strings.Join([]string{
+			"HTTP/1.1 101 Switching Protocols",
+			"Upgrade: websocket",
+			"Connection: Upgrade",
+			"", "",
+		}, "\r\n"))
+		require.NoError(t, err)
+		require.NoError(t, brw.Flush())
+
+		_, span := provider.Tracer("test").Start(otelhttp.SessionContext(r.Context()), "echo")
+		defer span.End()
+
+		frame := make([]byte, len(maskedFrame))
+		_, err = io.ReadFull(brw, frame)
+		require.NoError(t, err)
+		_, err = conn.Write(unmaskedFrame)
+		require.NoError(t, err)
+	})
+	ts := httptest.NewServer(otelhttp.NewHandler(handler, "ws",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithPropagators(propagation.TraceContext{}),
+		otelhttp.WithMessageEvents(otelhttp.WebsocketEvents),
+	))
+	defer ts.Close()
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, http.NoBody)
+	require.NoError(t, err)
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Connection", "Upgrade")
+
+	c := http.Client{Transport: otelhttp.NewTransport(
+		http.DefaultTransport,
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithPropagators(propagation.TraceContext{}),
+	)}
+	res, err := c.Do(r)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+
+	body, ok := res.Body.(io.ReadWriteCloser)
+	require.True(t, ok)
+	_, err = body.Write(maskedFrame)
+	require.NoError(t, err)
+	frame := make([]byte, len(unmaskedFrame))
+	_, err = io.ReadFull(bufio.NewReader(body), frame)
+	require.NoError(t, err)
+	assert.Equal(t, unmaskedFrame, frame)
+
+	_, span := provider.Tracer("test").Start(otelhttp.SessionContext(res.Request.Context()), "client")
+	span.End()
+	require.NoError(t, body.Close())
+	<-done
+
+	spans := sr.Ended()
+	require.Len(t, spans, 6)
+
+	serverHTTP := findSpan(t, spans, "ws", trace.SpanKindServer)
+	assert.Contains(t, serverHTTP.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusSwitchingProtocols))
+
+	serverSession := findSpan(t, spans, otelhttp.WebsocketSessionSpanName, trace.SpanKindServer)
+	assert.Equal(t, serverHTTP.SpanContext().SpanID(), serverSession.Parent().SpanID())
+	assert.Contains(t, serverSession.Attributes(), otelhttp.WebsocketFramesReceivedKey.Int64(1))
+	assert.Contains(t, serverSession.Attributes(), otelhttp.WebsocketBytesReceivedKey.Int64(int64(len(maskedFrame))))
+	assert.Contains(t, serverSession.Attributes(), otelhttp.WebsocketFramesSentKey.Int64(1))
+	assert.Contains(t, serverSession.Attributes(), otelhttp.WebsocketBytesSentKey.Int64(int64(len(unmaskedFrame))))
+	require.Len(t, serverSession.Events(), 2)
+	assert.Equal(t, "received", serverSession.Events()[0].Name)
+	assert.Equal(t, "sent", serverSession.Events()[1].Name)
+	for _, event := range serverSession.Events() {
+		assert.Equal(t, []attribute.KeyValue{
+			otelhttp.WebsocketMessageTypeKey.String("text"),
+			otelhttp.WebsocketMessageSizeKey.Int64(5),
+		}, event.Attributes)
+	}
+	echo := findSpan(t, spans, "echo", trace.SpanKindInternal)
+	assert.Equal(t, serverSession.SpanContext().SpanID(), echo.Parent().SpanID())
+
+	clientHTTP := findSpan(t, spans, "HTTP GET", trace.SpanKindClient)
+	assert.Equal(t, clientHTTP.SpanContext().SpanID(), serverHTTP.Parent().SpanID())
+
+	clientSession := findSpan(t, spans, otelhttp.WebsocketSessionSpanName, trace.SpanKindClient)
+	assert.Equal(t, clientHTTP.SpanContext().SpanID(), clientSession.Parent().SpanID())
+	assert.Contains(t, clientSession.Attributes(), otelhttp.WebsocketFramesSentKey.Int64(1))
+	assert.Contains(t, clientSession.Attributes(), otelhttp.WebsocketFramesReceivedKey.Int64(1))
+	assert.Empty(t, clientSession.Events())
+	client := findSpan(t, spans, "client", trace.SpanKindInternal)
+	assert.Equal(t, clientSession.SpanContext().SpanID(), client.Parent().SpanID())
+}
+
+func TestWebsocketUpgradeRefused(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	done := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// The handshake is refused once the connection is hijacked.
+		_, err = brw.WriteString(strings.Join([]string{
+			"HTTP/1.1 400 Bad Request",
+			"Content-Length: 0",
+			"Connection: close",
+			"", "",
+		}, "\r\n"))
+		require.NoError(t, err)
+		require.NoError(t, brw.Flush())
+		assert.Equal(t, r.Context(), otelhttp.SessionContext(r.Context()))
+	})
+	ts := httptest.NewServer(otelhttp.NewHandler(handler, "ws", otelhttp.WithTracerProvider(provider)))
+	defer ts.Close()
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, http.NoBody)
+	require.NoError(t, err)
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Connection", "Upgrade")
+
+	res, err := http.DefaultClient.Do(r)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	require.NoError(t, res.Body.Close())
+	<-done
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "ws", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusBadRequest))
+}
@@ -41,6 +41,7 @@ type Transport struct {
 	filters           []Filter
 	spanNameFormatter func(string, *http.Request) string
 	clientTrace       func(context.Context) *httptrace.ClientTrace
+	websocketEvent    bool
 	metadataOnly      bool
 }
 
@@ -79,6 +80,7 @@ func (t *Transport) applyConfig(c *config) {
 	t.filters = c.Filters
 	t.spanNameFormatter = c.SpanNameFormatter
 	t.clientTrace = c.ClientTrace
+	t.websocketEvent = c.WebsocketEvent
 }
 
 func defaultTransportFormatter(_ string, r *http.Request) string {
@@ -117,7 +119,7 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 		r.Body = &bw
 	}
 
-	ctx, span := tracer.Start(r.Context(), t.spanNameFormatter("", r), opts...)
+	ctx, span := tracer.Start(contextWithSessionHolder(r.Context()), t.spanNameFormatter("", r), opts...)
 	if !t.metadataOnly {
 		collectRequestHeaders(r, span)
 	}
@@ -145,6 +147,16 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 
 	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(res.StatusCode)...)
 	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(res.StatusCode))
+
+	if rwc, ok := res.Body.(io.ReadWriteCloser); ok && res.StatusCode == http.StatusSwitchingProtocols && isWebsocketUpgrade(res.Header) {
+		// The HTTP exchange ends with the upgrade, the websocket session
+		// starts and is tracked over the response body.
+		span.End()
+		session := startWebsocketSession(ctx, tracer, trace.SpanKindClient, t.websocketEvent, false)
+		res.Body = &sessionBody{body: rwc, session: session}
+		return res, err
+	}
+
 	respContentType := res.Header.Get("Content-Type")
 	res.Body = newWrappedBody(span, res.Body, t.metadataOnly, respContentType)
 
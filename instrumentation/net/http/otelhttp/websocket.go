@@ -0,0 +1,414 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelhttp // import "github.com/helios/opentelemetry-go-contrib/instrumentation/net/http/otelhttp"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WebsocketSessionSpanName is the name of the spans tracking websocket
+// sessions, started once a connection is upgraded.
+const WebsocketSessionSpanName = "websocket session"
+
+// Websocket session attribute keys.
+const (
+	WebsocketFramesSentKey     = attribute.Key("websocket.frames_sent")     // Number of frames sent over the session
+	WebsocketFramesReceivedKey = attribute.Key("websocket.frames_received") // Number of frames received over the session
+	WebsocketBytesSentKey      = attribute.Key("websocket.bytes_sent")      // Number of bytes of frames sent over the session
+	WebsocketBytesReceivedKey  = attribute.Key("websocket.bytes_received")  // Number of bytes of frames received over the session
+	WebsocketMessageTypeKey    = attribute.Key("websocket.message.type")    // Type of a websocket message: text, binary, close, ping or pong
+	WebsocketMessageSizeKey    = attribute.Key("websocket.message.size")    // Size of the payload of a websocket message
+)
+
+// Websocket opcodes, see RFC 6455 section 5.2.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+var messageTypes = map[byte]string{
+	opText:   "text",
+	opBinary: "binary",
+	opClose:  "close",
+	opPing:   "ping",
+	opPong:   "pong",
+}
+
+// isWebsocketUpgrade reports whether h holds the headers of a websocket
+// upgrade request or response.
+func isWebsocketUpgrade(h http.Header) bool {
+	if !strings.EqualFold(h.Get("Upgrade"), "websocket") {
+		return false
+	}
+	for _, v := range h.Values("Connection") {
+		for _, token := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type sessionKey struct{}
+
+// sessionHolder holds the span of the websocket session of a request, once
+// its connection is upgraded.
+type sessionHolder struct {
+	span trace.Span
+}
+
+// contextWithSessionHolder returns a copy of ctx holding the span of the
+// websocket session started for its request, if any.
+func contextWithSessionHolder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &sessionHolder{})
+}
+
+// SessionContext returns a copy of ctx carrying the span of the websocket
+// session started by the Handler or the Transport for the request of ctx, so
+// that the operations performed over the session are traced as its children.
+// ctx is the context of the request served by the Handler, or the context of
+// the request of a response returned by the Transport. If the connection of
+// the request was not upgraded to a websocket, ctx is returned.
+func SessionContext(ctx context.Context) context.Context {
+	if holder, ok := ctx.Value(sessionKey{}).(*sessionHolder); ok && holder.span != nil {
+		return trace.ContextWithSpan(ctx, holder.span)
+	}
+	return ctx
+}
+
+// websocketSession tracks the frames exchanged over an upgraded connection
+// with a span, ended when the connection is closed.
+type websocketSession struct {
+	span     trace.Span
+	sent     *frameCounter
+	received *frameCounter
+	once     sync.Once
+}
+
+// startWebsocketSession starts the span of a websocket session as a child of
+// the span in ctx. If events is true, an event is added to the span for every
+// message sent and received. If handshake is true, the handshake response is
+// expected to be sent over the session before any frame.
+func startWebsocketSession(ctx context.Context, tracer trace.Tracer, kind trace.SpanKind, events, handshake bool) *websocketSession {
+	_, span := tracer.Start(ctx, WebsocketSessionSpanName, trace.WithSpanKind(kind))
+	s := &websocketSession{span: span}
+	s.sent = newFrameCounter("sent", span, events, handshake)
+	s.received = newFrameCounter("received", span, events, false)
+	if holder, ok := ctx.Value(sessionKey{}).(*sessionHolder); ok {
+		holder.span = span
+	}
+	return s
+}
+
+func (s *websocketSession) end() {
+	s.once.Do(func() {
+		s.span.SetAttributes(
+			WebsocketFramesSentKey.Int64(atomic.LoadInt64(&s.sent.frames)),
+			WebsocketBytesSentKey.Int64(atomic.LoadInt64(&s.sent.bytes)),
+			WebsocketFramesReceivedKey.Int64(atomic.LoadInt64(&s.received.frames)),
+			WebsocketBytesReceivedKey.Int64(atomic.LoadInt64(&s.received.bytes)),
+		)
+		s.span.End()
+	})
+}
+
+// frameCounter parses the websocket frames flowing in one direction of a
+// session to count them. It is not safe for concurrent use, websocket
+// connections support one concurrent reader and one concurrent writer.
+type frameCounter struct {
+	event  string
+	span   trace.Span
+	events bool
+
+	frames int64
+	bytes  int64
+
+	// handshake is the number of bytes of the "\r\n\r\n" sequence ending
+	// the handshake seen so far, or -1 once it ended.
+	handshake int
+
+	header    [14]byte
+	headerLen int
+	remaining int64
+
+	// opcode and size of the current message, which may be fragmented.
+	opcode byte
+	size   int64
+}
+
+func newFrameCounter(event string, span trace.Span, events, handshake bool) *frameCounter {
+	c := &frameCounter{event: event, span: span, events: events, handshake: -1}
+	if handshake {
+		c.handshake = 0
+	}
+	return c
+}
+
+// observe parses the bytes p of the stream.
+func (c *frameCounter) observe(p []byte) {
+	for len(p) > 0 {
+		switch {
+		case c.handshake >= 0:
+			p = c.skipHandshake(p)
+		case c.remaining > 0:
+			n := int64(len(p))
+			if n > c.remaining {
+				n = c.remaining
+			}
+			atomic.AddInt64(&c.bytes, n)
+			c.remaining -= n
+			p = p[n:]
+		default:
+			atomic.AddInt64(&c.bytes, 1)
+			c.header[c.headerLen] = p[0]
+			c.headerLen++
+			p = p[1:]
+			c.parseHeader()
+		}
+	}
+}
+
+// skipHandshake skips the bytes of p up to the end of the handshake, and
+// returns the remaining ones.
+func (c *frameCounter) skipHandshake(p []byte) []byte {
+	const end = "\r\n\r\n"
+	for i, b := range p {
+		switch {
+		case b == end[c.handshake]:
+			c.handshake++
+		case b == end[0]:
+			c.handshake = 1
+		default:
+			c.handshake = 0
+		}
+		if c.handshake == len(end) {
+			c.handshake = -1
+			return p[i+1:]
+		}
+	}
+	return nil
+}
+
+// parseHeader parses the header of a frame once it is complete.
+func (c *frameCounter) parseHeader() {
+	if c.headerLen < 2 {
+		return
+	}
+	size := int64(c.header[1] & 0x7f)
+	n := 2
+	switch size {
+	case 126:
+		n += 2
+	case 127:
+		n += 8
+	}
+	if c.header[1]&0x80 != 0 {
+		n += 4 // masking key
+	}
+	if c.headerLen < n {
+		return
+	}
+	switch size {
+	case 126:
+		size = int64(binary.BigEndian.Uint16(c.header[2:4]))
+	case 127:
+		size = int64(binary.BigEndian.Uint64(c.header[2:10]) & (1<<63 - 1))
+	}
+	c.headerLen = 0
+	c.remaining = size
+	atomic.AddInt64(&c.frames, 1)
+
+	fin := c.header[0]&0x80 != 0
+	opcode := c.header[0] & 0x0f
+	switch {
+	case opcode >= opClose:
+		// Control frames are not fragmented, and may be interleaved with
+		// the fragments of a message.
+		c.message(opcode, size)
+	case opcode == opContinuation:
+		c.size += size
+		if fin {
+			c.message(c.opcode, c.size)
+		}
+	default:
+		c.opcode, c.size = opcode, size
+		if fin {
+			c.message(c.opcode, c.size)
+		}
+	}
+}
+
+// message records a complete message as a span event, if enabled.
+func (c *frameCounter) message(opcode byte, size int64) {
+	if !c.events {
+		return
+	}
+	typ, ok := messageTypes[opcode]
+	if !ok {
+		typ = "unknown"
+	}
+	c.span.AddEvent(c.event, trace.WithAttributes(
+		WebsocketMessageTypeKey.String(typ),
+		WebsocketMessageSizeKey.Int64(size),
+	))
+}
+
+// maxStatusLineLength is the maximum length of the status line of a
+// handshake response buffered to read its status code.
+const maxStatusLineLength = 256
+
+// sessionConn is a hijacked connection over which the handler answers a
+// websocket upgrade request. The websocket session tracking its frames only
+// starts once the handler writes a 101 status line.
+type sessionConn struct {
+	net.Conn
+	r io.Reader
+
+	// upgrade is called with the status code of the handshake response once
+	// its status line is written, and returns the session it starts, if any.
+	upgrade func(statusCode int) *websocketSession
+	// statusLine is the part of the status line written so far.
+	statusLine []byte
+
+	mu      sync.Mutex
+	session *websocketSession
+}
+
+// newSessionConn returns the connection and buffered reader and writer to
+// hand out in place of the ones returned by http.Hijacker.Hijack. Reads go
+// through the hijacked buffered reader, as it may hold data already received.
+func newSessionConn(conn net.Conn, brw *bufio.ReadWriter, upgrade func(statusCode int) *websocketSession) (net.Conn, *bufio.ReadWriter) {
+	c := &sessionConn{Conn: conn, r: brw.Reader, upgrade: upgrade}
+	return c, bufio.NewReadWriter(bufio.NewReader(c), bufio.NewWriter(c))
+}
+
+func (c *sessionConn) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if session := c.currentSession(); session != nil {
+		session.received.observe(p[:n])
+	}
+	return n, err
+}
+
+func (c *sessionConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	written := p[:n]
+	if c.upgrade != nil {
+		written = c.readStatusLine(written)
+	}
+	if session := c.currentSession(); session != nil {
+		session.sent.observe(written)
+	}
+	return n, err
+}
+
+// readStatusLine buffers the bytes of p up to the end of the status line of
+// the handshake response, and calls upgrade once the line is complete. It
+// returns the bytes to track with the session, starting with the status
+// line if the session started.
+func (c *sessionConn) readStatusLine(p []byte) []byte {
+	end := bytes.IndexByte(p, '\n') + 1
+	if end == 0 {
+		if len(c.statusLine)+len(p) < maxStatusLineLength {
+			c.statusLine = append(c.statusLine, p...)
+			return nil
+		}
+		// Too long to be a status line.
+		end = len(p)
+	}
+	line := append(c.statusLine, p[:end]...)
+	upgrade := c.upgrade
+	c.upgrade, c.statusLine = nil, nil
+
+	session := upgrade(statusCode(line))
+	if session == nil {
+		return nil
+	}
+	c.mu.Lock()
+	c.session = session
+	c.mu.Unlock()
+	return append(line, p[end:]...)
+}
+
+func (c *sessionConn) currentSession() *websocketSession {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.session
+}
+
+func (c *sessionConn) Close() error {
+	if session := c.currentSession(); session != nil {
+		session.end()
+	}
+	return c.Conn.Close()
+}
+
+// statusCode returns the status code of the HTTP/1.x status line, or 0 if
+// line is not one.
+func statusCode(line []byte) int {
+	fields := strings.Fields(string(line))
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "HTTP/") {
+		return 0
+	}
+	code, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0
+	}
+	return code
+}
+
+// sessionBody is the body of a response upgrading a connection to a
+// websocket, whose frames are tracked by a websocket session.
+type sessionBody struct {
+	body    io.ReadWriteCloser
+	session *websocketSession
+}
+
+var _ io.ReadWriteCloser = &sessionBody{}
+
+func (b *sessionBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.session.received.observe(p[:n])
+	return n, err
+}
+
+func (b *sessionBody) Write(p []byte) (int, error) {
+	n, err := b.body.Write(p)
+	b.session.sent.observe(p[:n])
+	return n, err
+}
+
+func (b *sessionBody) Close() error {
+	b.session.end()
+	return b.body.Close()
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelhttp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type eventSpan struct {
+	trace.Span
+
+	events [][]attribute.KeyValue
+}
+
+func (s *eventSpan) AddEvent(_ string, opts ...trace.EventOption) {
+	cfg := trace.NewEventConfig(opts...)
+	s.events = append(s.events, cfg.Attributes())
+}
+
+func message(typ string, size int64) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		WebsocketMessageTypeKey.String(typ),
+		WebsocketMessageSizeKey.Int64(size),
+	}
+}
+
+func TestFrameCounter(t *testing.T) {
+	payload := make([]byte, 300)
+	stream := []byte("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\n\r\n")
+	// Binary message fragmented in two frames, with a ping in between.
+	stream = append(stream, 0x02, 0x03, 'a', 'b', 'c')
+	stream = append(stream, 0x89, 0x00)
+	stream = append(stream, 0x80, 0x02, 'd', 'e')
+	// Masked text frame with a 16 bits length.
+	stream = append(stream, 0x81, 0xfe, 0x01, 0x2c, 0x01, 0x02, 0x03, 0x04)
+	stream = append(stream, payload...)
+	// Close frame with a 64 bits length.
+	stream = append(stream, 0x88, 0x7f, 0, 0, 0, 0, 0, 0, 0, 0x02, 0x03, 0xe8)
+
+	for _, chunk := range []int{1, 7, len(stream)} {
+		span := &eventSpan{}
+		c := newFrameCounter("sent", span, true, true)
+		for i := 0; i < len(stream); i += chunk {
+			end := i + chunk
+			if end > len(stream) {
+				end = len(stream)
+			}
+			c.observe(stream[i:end])
+		}
+
+		assert.Equal(t, int64(5), c.frames, "chunk %d", chunk)
+		assert.Equal(t, int64(len(stream)-56), c.bytes, "chunk %d", chunk)
+		assert.Equal(t, [][]attribute.KeyValue{
+			message("ping", 0),
+			message("binary", 5),
+			message("text", 300),
+			message("close", 2),
+		}, span.events, "chunk %d", chunk)
+	}
+}
+
+func TestIsWebsocketUpgrade(t *testing.T) {
+	assert.True(t, isWebsocketUpgrade(http.Header{"Upgrade": {"WebSocket"}, "Connection": {"keep-alive, Upgrade"}}))
+	assert.False(t, isWebsocketUpgrade(http.Header{"Upgrade": {"h2c"}, "Connection": {"Upgrade"}}))
+	assert.False(t, isWebsocketUpgrade(http.Header{"Upgrade": {"websocket"}}))
+}
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, http.StatusSwitchingProtocols, statusCode([]byte("HTTP/1.1 101 Switching Protocols\r\n")))
+	assert.Equal(t, http.StatusBadRequest, statusCode([]byte("HTTP/1.1 400\r\n")))
+	assert.Equal(t, 0, statusCode([]byte("\x81\x05hello")))
+	assert.Equal(t, 0, statusCode([]byte("HTTP/1.1 abc\r\n")))
+}
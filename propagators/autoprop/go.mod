@@ -6,6 +6,7 @@ require (
 	github.com/stretchr/testify v1.8.1
 	go.opentelemetry.io/contrib/propagators/aws v1.12.0
 	go.opentelemetry.io/contrib/propagators/b3 v1.12.0
+	go.opentelemetry.io/contrib/propagators/datadog v0.37.0
 	go.opentelemetry.io/contrib/propagators/jaeger v1.12.0
 	go.opentelemetry.io/contrib/propagators/ot v1.12.0
 	go.opentelemetry.io/otel v1.11.2
@@ -31,3 +32,5 @@ replace go.opentelemetry.io/contrib/propagators/b3 => ../b3
 replace go.opentelemetry.io/contrib/propagators/aws => ../aws
 
 replace go.opentelemetry.io/contrib/propagators/ot => ../ot
+
+replace go.opentelemetry.io/contrib/propagators/datadog => ../datadog
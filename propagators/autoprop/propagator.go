@@ -33,9 +33,9 @@ const otelPropagatorsEnvKey = "OTEL_PROPAGATORS"
 // to the once composited by props.
 //
 // The propagators supported with the OTEL_PROPAGATORS environment variable by
-// default are: tracecontext, baggage, b3, b3multi, jaeger, xray, ottrace, and
-// none. Each of these values, and their combination, are supported in
-// conformance with the OpenTelemetry specification. See
+// default are: tracecontext, baggage, b3, b3multi, jaeger, xray, ottrace,
+// datadog, and none. Each of these values, and their combination, are
+// supported in conformance with the OpenTelemetry specification. See
 // https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/sdk-environment-variables.md#general-sdk-configuration
 // for more information.
 //
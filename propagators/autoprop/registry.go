@@ -22,6 +22,7 @@ import (
 
 	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/datadog"
 	"go.opentelemetry.io/contrib/propagators/jaeger"
 	"go.opentelemetry.io/contrib/propagators/ot"
 	"go.opentelemetry.io/otel/propagation"
@@ -49,6 +50,8 @@ var propagators = &registry{
 		"xray": xray.Propagator{},
 		// OpenTracing Trace.
 		"ottrace": ot.OT{},
+		// Datadog.
+		"datadog": datadog.Datadog{},
 
 		// No-op TextMapPropagator.
 		none: propagation.NewCompositeTextMapPropagator(),
@@ -101,7 +104,7 @@ func (r *registry) drop(key string) {
 // RegisterTextMapPropagator sets the TextMapPropagator p to be used when the
 // OTEL_PROPAGATORS environment variable contains the propagator name. This
 // will panic if name has already been registered or is a default
-// (tracecontext, baggage, b3, b3multi, jaeger, xray, or ottrace).
+// (tracecontext, baggage, b3, b3multi, jaeger, xray, ottrace, or datadog).
 func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 	if err := propagators.store(name, p); err != nil {
 		// envRegistry.store will return errDupReg if name is already
@@ -121,7 +124,7 @@ func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 // passed names of registered TextMapPropagators. Each name must match an
 // already registered TextMapPropagator (see the RegisterTextMapPropagator
 // function for more information) or a default (tracecontext, baggage, b3,
-// b3multi, jaeger, xray, or ottrace).
+// b3multi, jaeger, xray, ottrace, or datadog).
 //
 // If "none" is included in the arguments, or no names are provided, the
 // returned TextMapPropagator will be a no-operation implementation.
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/contrib/propagators/datadog"
 	"go.opentelemetry.io/otel/propagation"
 )
 
@@ -85,3 +86,13 @@ func TestDuplicateRegisterTextMapPropagatorPanics(t *testing.T) {
 		RegisterTextMapPropagator(propName, noop)
 	})
 }
+
+func TestDatadogRegistered(t *testing.T) {
+	p, err := TextMapPropagator("datadog")
+	require.NoError(t, err)
+	assert.Equal(t, datadog.Datadog{}, p)
+
+	assert.Panics(t, func() {
+		RegisterTextMapPropagator("datadog", noop)
+	})
+}
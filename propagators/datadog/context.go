@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog // import "go.opentelemetry.io/contrib/propagators/datadog"
+
+import "context"
+
+type datadogKeyType int
+
+const (
+	samplingPriorityKey datadogKeyType = iota
+	tagsKey
+)
+
+// withSamplingPriority returns a copy of parent with priority set as the
+// sampling priority.
+func withSamplingPriority(parent context.Context, priority int) context.Context {
+	return context.WithValue(parent, samplingPriorityKey, priority)
+}
+
+// samplingPriorityFromContext returns the sampling priority stored in ctx.
+//
+// If no sampling priority is stored in ctx false is returned.
+func samplingPriorityFromContext(ctx context.Context) (int, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	priority, ok := ctx.Value(samplingPriorityKey).(int)
+	return priority, ok
+}
+
+// withTags returns a copy of parent with tags set as the propagated tags.
+func withTags(parent context.Context, tags []string) context.Context {
+	return context.WithValue(parent, tagsKey, tags)
+}
+
+// tagsFromContext returns the propagated tags stored in ctx, as key=value
+// pairs.
+//
+// If no tags are stored in ctx nil is returned.
+func tagsFromContext(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	if tags, ok := ctx.Value(tagsKey).([]string); ok {
+		return tags
+	}
+	return nil
+}
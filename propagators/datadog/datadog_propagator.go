@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog // import "go.opentelemetry.io/contrib/propagators/datadog"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Default Datadog Header names.
+	traceIDHeader          = "x-datadog-trace-id"
+	parentIDHeader         = "x-datadog-parent-id"
+	samplingPriorityHeader = "x-datadog-sampling-priority"
+	tagsHeader             = "x-datadog-tags"
+
+	// propagatedTagPrefix is the prefix of the trace tags Datadog tracers
+	// propagate in the x-datadog-tags header.
+	propagatedTagPrefix = "_dd.p."
+	// traceIDHighTag holds the high 64 bits of 128-bit trace IDs, as 16
+	// lowercase hex characters.
+	traceIDHighTag = "_dd.p.tid"
+	// maxTagsLength is the default limit of Datadog tracers for the length
+	// of the x-datadog-tags header. Longer values are ignored.
+	maxTagsLength = 512
+
+	traceIDHighWidth = 64 / 4 // 16 hex character high trace ID bits.
+
+	// Datadog sampling priorities. Traces with a positive priority are
+	// kept.
+	priorityUserReject = -1
+	priorityAutoReject = 0
+	priorityAutoKeep   = 1
+	priorityUserKeep   = 2
+)
+
+var (
+	empty = trace.SpanContext{}
+
+	errInvalidTraceIDHeader          = errors.New("invalid Datadog trace ID header found")
+	errInvalidParentIDHeader         = errors.New("invalid Datadog parent ID header found")
+	errInvalidSamplingPriorityHeader = errors.New("invalid Datadog sampling priority header found")
+)
+
+// Datadog propagator serializes SpanContext to/from x-datadog-* headers.
+//
+// Datadog format:
+//
+//	x-datadog-trace-id: {low 64 bits of the trace ID, decimal}
+//	x-datadog-parent-id: {span ID, decimal}
+//	x-datadog-sampling-priority: {-1, 0, 1 or 2}
+//	x-datadog-tags: _dd.p.tid={high 64 bits of the trace ID, hex},{other _dd.p.* tags}
+//
+// A positive sampling priority is extracted as the sampled trace flag. The
+// extracted priority and propagated tags are injected back as long as the
+// priority agrees with the sampled flag of the span context.
+type Datadog struct{}
+
+var _ propagation.TextMapPropagator = Datadog{}
+
+// Inject injects a context into the carrier as Datadog headers.
+func (d Datadog) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanFromContext(ctx).SpanContext()
+	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
+		return
+	}
+
+	traceID, spanID := sc.TraceID(), sc.SpanID()
+	carrier.Set(traceIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(traceID[8:]), 10))
+	carrier.Set(parentIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(spanID[:]), 10))
+	carrier.Set(samplingPriorityHeader, strconv.Itoa(samplingPriority(ctx, sc)))
+
+	var tags []string
+	if high := binary.BigEndian.Uint64(traceID[:8]); high != 0 {
+		tags = append(tags, fmt.Sprintf("%s=%016x", traceIDHighTag, high))
+	}
+	tags = append(tags, tagsFromContext(ctx)...)
+	if h := strings.Join(tags, ","); h != "" && len(h) <= maxTagsLength {
+		carrier.Set(tagsHeader, h)
+	}
+}
+
+// samplingPriority returns the sampling priority to inject for sc. The
+// priority stored in ctx is used if it agrees with the sampled flag of sc.
+func samplingPriority(ctx context.Context, sc trace.SpanContext) int {
+	if p, ok := samplingPriorityFromContext(ctx); ok && (p > priorityAutoReject) == sc.IsSampled() {
+		return p
+	}
+	if sc.IsSampled() {
+		return priorityAutoKeep
+	}
+	return priorityAutoReject
+}
+
+// Extract extracts a context from the carrier if it contains Datadog headers.
+func (d Datadog) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	var (
+		traceID  = carrier.Get(traceIDHeader)
+		parentID = carrier.Get(parentIDHeader)
+		priority = carrier.Get(samplingPriorityHeader)
+	)
+	if traceID == "" && parentID == "" {
+		return ctx
+	}
+
+	high, tags := parseTags(carrier.Get(tagsHeader))
+	sc, err := extract(traceID, parentID, priority, high)
+	if err != nil || !sc.IsValid() {
+		return ctx
+	}
+
+	if priority != "" {
+		// Validated by extract.
+		p, _ := strconv.Atoi(priority)
+		ctx = withSamplingPriority(ctx, p)
+	}
+	if len(tags) > 0 {
+		ctx = withTags(ctx, tags)
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the Datadog header keys whose values are set with Inject.
+func (d Datadog) Fields() []string {
+	return []string{traceIDHeader, parentIDHeader, samplingPriorityHeader, tagsHeader}
+}
+
+// extract reconstructs a SpanContext from header values based on Datadog
+// headers. high holds the high 64 bits of the trace ID.
+func extract(traceID, parentID, priority string, high uint64) (trace.SpanContext, error) {
+	var scc trace.SpanContextConfig
+
+	low, err := strconv.ParseUint(traceID, 10, 64)
+	if err != nil || low == 0 {
+		return empty, errInvalidTraceIDHeader
+	}
+	binary.BigEndian.PutUint64(scc.TraceID[:8], high)
+	binary.BigEndian.PutUint64(scc.TraceID[8:], low)
+
+	id, err := strconv.ParseUint(parentID, 10, 64)
+	if err != nil || id == 0 {
+		return empty, errInvalidParentIDHeader
+	}
+	binary.BigEndian.PutUint64(scc.SpanID[:], id)
+
+	if priority != "" {
+		p, err := strconv.Atoi(priority)
+		if err != nil {
+			return empty, errInvalidSamplingPriorityHeader
+		}
+		if p > priorityAutoReject {
+			scc.TraceFlags = trace.FlagsSampled
+		}
+	}
+
+	return trace.NewSpanContext(scc), nil
+}
+
+// parseTags returns the high 64 bits of the trace ID and the other
+// propagated tags found in a x-datadog-tags header. Tags are ignored if the
+// header exceeds maxTagsLength, and a malformed _dd.p.tid tag is dropped.
+func parseTags(h string) (uint64, []string) {
+	if h == "" || len(h) > maxTagsLength {
+		return 0, nil
+	}
+
+	var (
+		high uint64
+		tags []string
+	)
+	for _, tag := range strings.Split(h, ",") {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) != 2 || !strings.HasPrefix(kv[0], propagatedTagPrefix) || kv[1] == "" {
+			continue
+		}
+		if kv[0] != traceIDHighTag {
+			tags = append(tags, tag)
+			continue
+		}
+		if len(kv[1]) != traceIDHighWidth || strings.ToLower(kv[1]) != kv[1] {
+			continue
+		}
+		if v, err := strconv.ParseUint(kv[1], 16, 64); err == nil {
+			high = v
+		}
+	}
+	return high, tags
+}
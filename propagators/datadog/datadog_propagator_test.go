@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	traceID64  = trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0xab, 0x54, 0xa9, 0x8c, 0xeb, 0x1f, 0x0a, 0xd2}
+	traceID128 = trace.TraceID{0x64, 0x0c, 0xfd, 0x8d, 0, 0, 0, 0, 0xab, 0x54, 0xa9, 0x8c, 0xeb, 0x1f, 0x0a, 0xd2}
+	spanID     = trace.SpanID{0, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+
+	traceIDStr = "12345678901234567890"
+	spanIDStr  = "67667974448284343"
+	highStr    = "640cfd8d00000000"
+)
+
+func TestExtract(t *testing.T) {
+	testCases := []struct {
+		name     string
+		headers  map[string]string
+		expected trace.SpanContextConfig
+		priority int
+		// hasPriority reports whether a sampling priority is expected in
+		// the extracted context.
+		hasPriority bool
+		tags        []string
+	}{
+		{
+			name:    "empty",
+			headers: map[string]string{},
+		},
+		{
+			name: "sampled",
+			headers: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+			},
+			expected:    trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			priority:    priorityAutoKeep,
+			hasPriority: true,
+		},
+		{
+			name: "user keep",
+			headers: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "2",
+			},
+			expected:    trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			priority:    priorityUserKeep,
+			hasPriority: true,
+		},
+		{
+			name: "user reject",
+			headers: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "-1",
+			},
+			expected:    trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID},
+			priority:    priorityUserReject,
+			hasPriority: true,
+		},
+		{
+			name: "no sampling priority",
+			headers: map[string]string{
+				traceIDHeader:  traceIDStr,
+				parentIDHeader: spanIDStr,
+			},
+			expected: trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID},
+		},
+		{
+			name: "128-bit trace ID",
+			headers: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+				tagsHeader:             "_dd.p.dm=-4,_dd.p.tid=" + highStr + ",env=prod",
+			},
+			expected:    trace.SpanContextConfig{TraceID: traceID128, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			priority:    priorityAutoKeep,
+			hasPriority: true,
+			tags:        []string{"_dd.p.dm=-4"},
+		},
+		{
+			name: "malformed high trace ID bits",
+			headers: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "0",
+				tagsHeader:             "_dd.p.tid=640CFD8D00000000",
+			},
+			expected:    trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID},
+			priority:    priorityAutoReject,
+			hasPriority: true,
+		},
+		{
+			name: "invalid trace ID",
+			headers: map[string]string{
+				traceIDHeader:  "abc",
+				parentIDHeader: spanIDStr,
+			},
+		},
+		{
+			name: "zero trace ID",
+			headers: map[string]string{
+				traceIDHeader:  "0",
+				parentIDHeader: spanIDStr,
+			},
+		},
+		{
+			name: "missing parent ID",
+			headers: map[string]string{
+				traceIDHeader: traceIDStr,
+			},
+		},
+		{
+			name: "invalid sampling priority",
+			headers: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "keep",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := Datadog{}.Extract(context.Background(), propagation.MapCarrier(tc.headers))
+
+			sc := trace.SpanContextFromContext(ctx)
+			assert.Equal(t, tc.expected.TraceID, sc.TraceID())
+			assert.Equal(t, tc.expected.SpanID, sc.SpanID())
+			assert.Equal(t, tc.expected.TraceFlags, sc.TraceFlags())
+			assert.Equal(t, sc.IsValid(), sc.IsRemote())
+
+			priority, ok := samplingPriorityFromContext(ctx)
+			assert.Equal(t, tc.hasPriority, ok)
+			assert.Equal(t, tc.priority, priority)
+			assert.Equal(t, tc.tags, tagsFromContext(ctx))
+		})
+	}
+}
+
+func TestInject(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		scc      trace.SpanContextConfig
+		expected map[string]string
+	}{
+		{
+			name:     "invalid span context",
+			ctx:      context.Background(),
+			expected: map[string]string{},
+		},
+		{
+			name: "sampled",
+			ctx:  context.Background(),
+			scc:  trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			expected: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+			},
+		},
+		{
+			name: "not sampled",
+			ctx:  context.Background(),
+			scc:  trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID},
+			expected: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "0",
+			},
+		},
+		{
+			name: "128-bit trace ID",
+			ctx:  withTags(context.Background(), []string{"_dd.p.dm=-4"}),
+			scc:  trace.SpanContextConfig{TraceID: traceID128, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			expected: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+				tagsHeader:             "_dd.p.tid=" + highStr + ",_dd.p.dm=-4",
+			},
+		},
+		{
+			name: "extracted sampling priority",
+			ctx:  withSamplingPriority(context.Background(), priorityUserKeep),
+			scc:  trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			expected: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "2",
+			},
+		},
+		{
+			name: "sampling priority overridden by the sampled flag",
+			ctx:  withSamplingPriority(context.Background(), priorityUserReject),
+			scc:  trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			expected: map[string]string{
+				traceIDHeader:          traceIDStr,
+				parentIDHeader:         spanIDStr,
+				samplingPriorityHeader: "1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			carrier := propagation.MapCarrier{}
+			ctx := trace.ContextWithSpanContext(tc.ctx, trace.NewSpanContext(tc.scc))
+			Datadog{}.Inject(ctx, carrier)
+			assert.Equal(t, tc.expected, map[string]string(carrier))
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	headers := propagation.MapCarrier{
+		traceIDHeader:          traceIDStr,
+		parentIDHeader:         spanIDStr,
+		samplingPriorityHeader: "2",
+		tagsHeader:             "_dd.p.tid=" + highStr + ",_dd.p.dm=-3",
+	}
+	ctx := Datadog{}.Extract(context.Background(), headers)
+
+	carrier := propagation.MapCarrier{}
+	Datadog{}.Inject(ctx, carrier)
+	assert.Equal(t, propagation.MapCarrier{
+		traceIDHeader:          traceIDStr,
+		parentIDHeader:         spanIDStr,
+		samplingPriorityHeader: "2",
+		tagsHeader:             "_dd.p.tid=" + highStr + ",_dd.p.dm=-3",
+	}, carrier)
+}
+
+func TestParseTagsLimit(t *testing.T) {
+	h := "_dd.p.tid=" + highStr + ",_dd.p.usr=" + string(make([]byte, maxTagsLength))
+	high, tags := parseTags(h)
+	assert.Zero(t, high)
+	assert.Nil(t, tags)
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{
+		"x-datadog-trace-id",
+		"x-datadog-parent-id",
+		"x-datadog-sampling-priority",
+		"x-datadog-tags",
+	}, Datadog{}.Fields())
+}
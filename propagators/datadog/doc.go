@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datadog implements the propagation format of the Datadog tracing
+// libraries, based on the x-datadog-* headers.
+//
+// Datadog trace IDs are 64 bits. The high 64 bits of 128-bit trace IDs are
+// propagated as the _dd.p.tid tag of the x-datadog-tags header.
+package datadog // import "go.opentelemetry.io/contrib/propagators/datadog"
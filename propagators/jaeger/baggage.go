@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger // import "go.opentelemetry.io/contrib/propagators/jaeger"
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// baggageHeader is set by Jaeger clients to provide baggage for
+	// requests that are not part of a trace, as a comma separated list of
+	// key=value pairs.
+	baggageHeader = "jaeger-baggage"
+	// baggageHeaderPrefix is the prefix of the headers carrying one baggage
+	// item each.
+	baggageHeaderPrefix = "uberctx-"
+
+	// Limits of the W3C Baggage specification, also applied to extracted
+	// Jaeger baggage so that it can be propagated further.
+	maxBaggageMembers        = 180
+	maxBytesPerBaggageMember = 4096
+	maxBytesPerBaggageString = 8192
+)
+
+// injectBaggage sets an uberctx- prefixed key for every member of the baggage
+// in ctx. Values are URL encoded as Jaeger clients do.
+func injectBaggage(ctx context.Context, carrier propagation.TextMapCarrier) {
+	for _, m := range baggage.FromContext(ctx).Members() {
+		carrier.Set(baggageHeaderPrefix+m.Key(), url.QueryEscape(m.Value()))
+	}
+}
+
+// extractBaggage returns a copy of ctx with the baggage from the
+// jaeger-baggage and uberctx- prefixed keys of the carrier merged into the
+// baggage already in ctx. Items from uberctx- prefixed keys take precedence.
+// Items that are invalid, or that would make the baggage exceed the limits of
+// the W3C Baggage specification, are dropped.
+func extractBaggage(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	values := make(map[string]string)
+	if h := carrier.Get(baggageHeader); h != "" {
+		parseBaggageHeader(h, values)
+	}
+	for _, k := range carrier.Keys() {
+		key := strings.ToLower(k)
+		if !strings.HasPrefix(key, baggageHeaderPrefix) || len(key) == len(baggageHeaderPrefix) {
+			continue
+		}
+		v, err := url.QueryUnescape(carrier.Get(k))
+		if err != nil {
+			continue
+		}
+		values[key[len(baggageHeaderPrefix):]] = v
+	}
+	if len(values) == 0 {
+		return ctx
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	extracted := make([]baggage.Member, 0, len(keys))
+	overridden := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		// NewMember expects an encoded value.
+		m, err := baggage.NewMember(k, url.QueryEscape(values[k]))
+		if err != nil || len(m.String()) > maxBytesPerBaggageMember {
+			continue
+		}
+		extracted = append(extracted, m)
+		overridden[k] = struct{}{}
+	}
+	if len(extracted) == 0 {
+		return ctx
+	}
+
+	// Keep the members already in ctx that are not overridden.
+	var members []baggage.Member
+	for _, m := range baggage.FromContext(ctx).Members() {
+		if _, ok := overridden[m.Key()]; !ok {
+			members = append(members, m)
+		}
+	}
+
+	var (
+		list []baggage.Member
+		size int
+	)
+	for _, m := range append(members, extracted...) {
+		n := len(m.String())
+		if len(list) > 0 {
+			// Account for the separating comma.
+			n++
+		}
+		if len(list) == maxBaggageMembers || size+n > maxBytesPerBaggageString {
+			break
+		}
+		list = append(list, m)
+		size += n
+	}
+
+	bag, err := baggage.New(list...)
+	if err != nil {
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+// parseBaggageHeader adds the key=value pairs of a jaeger-baggage header to
+// values. Keys and values are unescaped once split, so that they can hold
+// escaped commas and equals signs. Malformed pairs are ignored.
+func parseBaggageHeader(h string, values map[string]string) {
+	for _, pair := range strings.Split(h, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		k, err := url.QueryUnescape(strings.TrimSpace(kv[0]))
+		if err != nil || k == "" {
+			continue
+		}
+		v, err := url.QueryUnescape(strings.TrimSpace(kv[1]))
+		if err != nil {
+			continue
+		}
+		values[k] = v
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestInjectBaggage(t *testing.T) {
+	user, err := baggage.NewMember("user", "alice%20smith")
+	require.NoError(t, err)
+	tenant, err := baggage.NewMember("tenant", "acme")
+	require.NoError(t, err)
+	bag, err := baggage.New(user, tenant)
+	require.NoError(t, err)
+
+	header := http.Header{}
+	jaeger.Jaeger{}.Inject(baggage.ContextWithBaggage(context.Background(), bag), propagation.HeaderCarrier(header))
+
+	assert.Equal(t, "alice+smith", header.Get("uberctx-user"))
+	assert.Equal(t, "acme", header.Get("uberctx-tenant"))
+	assert.Empty(t, header.Get("uber-trace-id"))
+}
+
+func TestExtractBaggage(t *testing.T) {
+	testCases := []struct {
+		name    string
+		headers map[string]string
+		want    map[string]string
+	}{
+		{
+			name:    "uberctx headers",
+			headers: map[string]string{"Uberctx-User": "alice+smith", "uberctx-tenant": "acme%2Ccorp"},
+			want:    map[string]string{"user": "alice smith", "tenant": "acme,corp"},
+		},
+		{
+			name:    "jaeger-baggage header",
+			headers: map[string]string{"jaeger-baggage": "user=alice, tenant = acme,malformed"},
+			want:    map[string]string{"user": "alice", "tenant": "acme"},
+		},
+		{
+			name:    "jaeger-baggage header with escaped separators",
+			headers: map[string]string{"jaeger-baggage": "user=alice%2Csmith,expr=a%3Db,bad=%zz,%74enant=acme"},
+			want:    map[string]string{"user": "alice,smith", "expr": "a=b", "tenant": "acme"},
+		},
+		{
+			name:    "uberctx headers take precedence",
+			headers: map[string]string{"jaeger-baggage": "user=bob,tenant=acme", "uberctx-user": "alice"},
+			want:    map[string]string{"user": "alice", "tenant": "acme"},
+		},
+		{
+			name:    "invalid values are dropped",
+			headers: map[string]string{"uberctx-user": "%zz", "uberctx-tenant": "acme", "uberctx-": "empty"},
+			want:    map[string]string{"tenant": "acme"},
+		},
+		{
+			name:    "no baggage",
+			headers: map[string]string{"uber-trace-id": "000000000000007b:00000000000001c8:0:1"},
+			want:    map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tc.headers {
+				header.Set(k, v)
+			}
+
+			ctx := jaeger.Jaeger{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+
+			got := make(map[string]string)
+			for _, m := range baggage.FromContext(ctx).Members() {
+				got[m.Key()] = m.Value()
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestExtractBaggageMergesContext(t *testing.T) {
+	region, err := baggage.NewMember("region", "eu")
+	require.NoError(t, err)
+	user, err := baggage.NewMember("user", "bob")
+	require.NoError(t, err)
+	bag, err := baggage.New(region, user)
+	require.NoError(t, err)
+
+	header := http.Header{}
+	header.Set("uberctx-user", "alice")
+	ctx := jaeger.Jaeger{}.Extract(baggage.ContextWithBaggage(context.Background(), bag), propagation.HeaderCarrier(header))
+
+	got := baggage.FromContext(ctx)
+	assert.Equal(t, "eu", got.Member("region").Value())
+	assert.Equal(t, "alice", got.Member("user").Value())
+}
+
+func TestExtractBaggageLimits(t *testing.T) {
+	header := http.Header{}
+	header.Set("uberctx-large", strings.Repeat("a", 5000))
+	for i := 0; i < 200; i++ {
+		header.Set(fmt.Sprintf("uberctx-key%03d", i), "value")
+	}
+
+	ctx := jaeger.Jaeger{}.Extract(context.Background(), propagation.HeaderCarrier(header))
+
+	bag := baggage.FromContext(ctx)
+	assert.Equal(t, 180, bag.Len())
+	assert.LessOrEqual(t, len(bag.String()), 8192)
+	assert.Empty(t, bag.Member("large").Key())
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{"uber-trace-id"}, jaeger.Jaeger{}.Fields())
+}
@@ -56,13 +56,19 @@ var (
 // Jaeger format:
 //
 // uber-trace-id: {trace-id}:{span-id}:{parent-span-id}:{flags}.
+//
+// Baggage is propagated in uberctx-{key} headers, and is also extracted from
+// the jaeger-baggage header: {key1}={value1},{key2}={value2}.
 type Jaeger struct{}
 
 var _ propagation.TextMapPropagator = &Jaeger{}
 
 // Inject injects a context to the carrier following jaeger format.
 // The parent span ID is set to an dummy parent span id as the most implementations do.
+// Baggage in ctx is injected even if ctx does not hold a valid span context.
 func (jaeger Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	injectBaggage(ctx, carrier)
+
 	sc := trace.SpanFromContext(ctx).SpanContext()
 	headers := []string{}
 	if !sc.TraceID().IsValid() || !sc.SpanID().IsValid() {
@@ -82,6 +88,8 @@ func (jaeger Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarr
 
 // Extract extracts a context from the carrier if it contains Jaeger headers.
 func (jaeger Jaeger) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	ctx = extractBaggage(ctx, carrier)
+
 	// extract tracing information
 	if h := carrier.Get(jaegerHeader); h != "" {
 		ctx, sc, err := extract(ctx, h)
@@ -161,7 +169,10 @@ func extract(ctx context.Context, headerVal string) (context.Context, trace.Span
 	return ctx, trace.NewSpanContext(scc), nil
 }
 
-// Fields returns the Jaeger header key whose value is set with Inject.
+// Fields returns the Jaeger header key whose value is set with Inject. The
+// uberctx-{key} baggage headers are named after the baggage members, so they
+// cannot be listed and are not returned: carriers cleared or allowed using
+// Fields also need to account for the headers with the uberctx- prefix.
 func (jaeger Jaeger) Fields() []string {
 	return []string{jaegerHeader}
 }